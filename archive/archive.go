@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"fmt"
+	"tallyGo/countable"
+	EventBus "tallyGo/eventBus"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+	"github.com/diamondburned/gotk4/pkg/pango"
+)
+
+// ArchivePage lists the archived counters, they can be searched and moved
+// back into the treeview
+type ArchivePage struct {
+	*gtk.ScrolledWindow
+
+	list   *countable.CounterList
+	search *gtk.SearchEntry
+	rows   *gtk.ListBox
+	empty  *gtk.Label
+}
+
+func NewArchivePage(list *countable.CounterList) (self *ArchivePage) {
+	self = &ArchivePage{
+		gtk.NewScrolledWindow(),
+		list,
+		gtk.NewSearchEntry(),
+		gtk.NewListBox(),
+		gtk.NewLabel("No archived hunts"),
+	}
+
+	box := gtk.NewBox(gtk.OrientationVertical, 0)
+	box.AddCSSClass("archiveBox")
+	box.Append(self.search)
+	box.Append(self.rows)
+	box.Append(self.empty)
+	self.SetChild(box)
+
+	self.search.SetObjectProperty("placeholder-text", "Search name, tag, species, game or method")
+	self.search.ConnectSearchChanged(self.Update)
+	self.rows.SetSelectionMode(gtk.SelectionNone)
+	self.rows.AddCSSClass("archiveList")
+	self.empty.AddCSSClass("archiveEmptyLabel")
+
+	self.ConnectMap(self.Update)
+	EventBus.GetGlobalBus().Subscribe(countable.CounterArchived, func(...interface{}) {
+		self.Update()
+	})
+	EventBus.GetGlobalBus().Subscribe(countable.CounterRestored, func(...interface{}) {
+		self.Update()
+	})
+	self.Update()
+
+	return
+}
+
+func (self *ArchivePage) Update() {
+	for child := self.rows.FirstChild(); child != nil; child = self.rows.FirstChild() {
+		self.rows.Remove(child)
+	}
+
+	shown := 0
+	for _, counter := range self.list.Archive {
+		if self.list.Matches(counter, self.search.Text()) {
+			self.rows.Append(self.newRow(counter))
+			shown++
+		}
+	}
+	self.empty.SetVisible(shown == 0)
+}
+
+func (self *ArchivePage) newRow(counter *countable.Counter) *gtk.Box {
+	row := gtk.NewBox(gtk.OrientationHorizontal, 0)
+	row.AddCSSClass("archiveRow")
+
+	labels := gtk.NewBox(gtk.OrientationVertical, 0)
+	labels.SetHExpand(true)
+	name := gtk.NewLabel(counter.Name)
+	name.SetXAlign(0)
+	description := gtk.NewLabel(fmt.Sprintf("%s | %d encounters | %s",
+		counter.Description(), counter.GetCount(), status(counter)))
+	description.SetXAlign(0)
+	description.SetEllipsize(pango.EllipsizeEnd)
+	description.AddCSSClass("counterTargetLabel")
+	labels.Append(name)
+	labels.Append(description)
+
+	restore := gtk.NewButtonWithLabel("Restore")
+	restore.SetVAlign(gtk.AlignCenter)
+	restore.ConnectClicked(func() {
+		countable.GetUndoStack().Do(countable.NewRestoreCommand(self.list, counter))
+	})
+
+	row.Append(labels)
+	row.Append(restore)
+	return row
+}
+
+func status(counter *countable.Counter) string {
+	if counter.IsCompleted() {
+		return "completed"
+	}
+	return "not completed"
+}
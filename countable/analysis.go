@@ -0,0 +1,97 @@
+package countable
+
+import (
+	"math"
+	"sort"
+)
+
+// LuckAnalysis compares the luck percentiles of completed phases to the
+// uniform distribution they follow when the odds are right and the hunter
+// is neither lucky nor unlucky
+type LuckAnalysis struct {
+	// luck percentile of every completed phase, sorted
+	Percentiles []float64
+	// largest distance between the observed and the uniform distribution
+	KS     float64
+	PValue float64
+}
+
+// AnalyzeLuck collects the luck percentile of every completed phase
+func (self *CounterList) AnalyzeLuck() (analysis LuckAnalysis) {
+	for _, phase := range self.Phases() {
+		if percentile, ok := phase.LuckPercentile(); ok {
+			analysis.Percentiles = append(analysis.Percentiles, percentile)
+		}
+	}
+	sort.Float64s(analysis.Percentiles)
+
+	analysis.KS = ksUniform(analysis.Percentiles)
+	analysis.PValue = ksPValue(analysis.KS, len(analysis.Percentiles))
+	return
+}
+
+// ksUniform is the Kolmogorov-Smirnov statistic of sorted samples against
+// the uniform distribution on [0, 1]
+func ksUniform(sorted []float64) (distance float64) {
+	n := float64(len(sorted))
+	for idx, value := range sorted {
+		distance = math.Max(distance, float64(idx+1)/n-value)
+		distance = math.Max(distance, value-float64(idx)/n)
+	}
+	return
+}
+
+// ksPValue uses the asymptotic Kolmogorov distribution with the correction
+// for small samples by Stephens
+func ksPValue(distance float64, n int) float64 {
+	if n == 0 {
+		return 1
+	}
+	sqrtN := math.Sqrt(float64(n))
+	lambda := (sqrtN + 0.12 + 0.11/sqrtN) * distance
+	if lambda < 0.2 {
+		return 1
+	}
+
+	sum, sign := 0.0, 1.0
+	for k := 1; k <= 100; k++ {
+		term := sign * math.Exp(-2*float64(k*k)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-10 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(math.Min(2*sum, 1), 0)
+}
+
+// Histogram counts the percentiles in equally wide bins
+func (self LuckAnalysis) Histogram(bins int) []int {
+	counts := make([]int, bins)
+	for _, percentile := range self.Percentiles {
+		counts[min(int(percentile*float64(bins)), bins-1)]++
+	}
+	return counts
+}
+
+// Quantiles pairs every observed percentile with where it would be expected
+// under the uniform distribution, for a Q-Q plot
+func (self LuckAnalysis) Quantiles() (expected []float64, observed []float64) {
+	n := float64(len(self.Percentiles))
+	for idx, percentile := range self.Percentiles {
+		expected = append(expected, (float64(idx)+0.5)/n)
+		observed = append(observed, percentile)
+	}
+	return
+}
+
+// Mean luck percentile, below 0.5 the shinies came sooner than expected
+func (self LuckAnalysis) Mean() (mean float64) {
+	if len(self.Percentiles) == 0 {
+		return 0.5
+	}
+	for _, percentile := range self.Percentiles {
+		mean += percentile
+	}
+	return mean / float64(len(self.Percentiles))
+}
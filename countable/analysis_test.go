@@ -0,0 +1,58 @@
+package countable
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKSUniform(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		want   float64
+	}{
+		{"single median", []float64{.5}, .5},
+		{"evenly spread", []float64{.125, .375, .625, .875}, .125},
+		{"all lucky", []float64{0, 0, 0, 0}, 1},
+		{"all unlucky", []float64{1, 1}, 1},
+		{"skewed", []float64{.1, .2, .3}, 0.7},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ksUniform(test.sorted); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("got %g, want %g", got, test.want)
+			}
+		})
+	}
+}
+
+func TestKSPValue(t *testing.T) {
+	// distance for n samples that gives lambda in the Kolmogorov distribution
+	distance := func(lambda float64, n int) float64 {
+		sqrtN := math.Sqrt(float64(n))
+		return lambda / (sqrtN + 0.12 + 0.11/sqrtN)
+	}
+
+	tests := []struct {
+		name     string
+		distance float64
+		n        int
+		want     float64
+	}{
+		{"no samples", .5, 0, 1},
+		{"tiny distance", distance(.1, 50), 50, 1},
+		{"10% level", distance(1.2238, 100), 100, .10},
+		{"5% level", distance(1.3581, 40), 40, .05},
+		{"1% level", distance(1.6276, 1000), 1000, .01},
+		{"far off", distance(4, 10), 10, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ksPValue(test.distance, test.n); math.Abs(got-test.want) > 1e-3 {
+				t.Errorf("got %.4f, want %.4f", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package countable
+
+import (
+	"log"
+	EventBus "tallyGo/eventBus"
+)
+
+// ArchiveCounter moves the counter from the list to the archive
+func (self *CounterList) ArchiveCounter(counter *Counter) {
+	idx, ok := self.GetIdx(counter)
+	if !ok {
+		log.Println("[WARN]\tTried to archive a non existent Counter")
+		return
+	}
+	EventBus.GetGlobalBus().SendSignal(CounterRemoved, counter)
+	self.List = append(self.List[:idx], self.List[idx+1:]...)
+	self.Archive = append(self.Archive, counter)
+	EventBus.GetGlobalBus().SendSignal(CounterArchived, counter)
+}
+
+// RestoreCounter moves an archived counter back into the list at idx
+func (self *CounterList) RestoreCounter(idx int, counter *Counter) {
+	archiveIdx, ok := self.getArchiveIdx(counter)
+	if !ok {
+		log.Println("[WARN]\tTried to restore a Counter that is not archived")
+		return
+	}
+	self.Archive = append(self.Archive[:archiveIdx], self.Archive[archiveIdx+1:]...)
+	self.InsertCounter(idx, counter)
+	EventBus.GetGlobalBus().SendSignal(CounterRestored, counter)
+}
+
+func (self *CounterList) getArchiveIdx(counter *Counter) (int, bool) {
+	for idx, c := range self.Archive {
+		if c == counter {
+			return idx, true
+		}
+	}
+	return 0, false
+}
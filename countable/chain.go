@@ -0,0 +1,255 @@
+package countable
+
+import (
+	"encoding/json"
+	"math"
+)
+
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        SOS,
+		Codec:       "ChainHunt",
+		Aliases:     []string{"SOSBattle"},
+		DisplayName: "SOS Battle",
+		Odds:        4096,
+		IsChain:     true,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewChainHunt(SOS, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalChainHunt(SOS),
+	})
+	RegisterMethod(ProgressMethod{
+		Type:        PokeRadar,
+		Codec:       "ChainHunt",
+		DisplayName: "Poké Radar",
+		IsChain:     true,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewChainHunt(PokeRadar, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalChainHunt(PokeRadar),
+	})
+	RegisterMethod(ProgressMethod{
+		Type:        ChainFishing,
+		Codec:       "ChainHunt",
+		DisplayName: "Chain Fishing",
+		Odds:        4096,
+		IsChain:     true,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewChainHunt(ChainFishing, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalChainHunt(ChainFishing),
+	})
+	RegisterMethod(ProgressMethod{
+		Type:        CatchCombo,
+		Codec:       "ChainHunt",
+		DisplayName: "Catch Combo",
+		Odds:        4096,
+		IsChain:     true,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewChainHunt(CatchCombo, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalChainHunt(CatchCombo),
+	})
+}
+
+// Chainer is implemented by Progress types where the odds depend on the
+// length of the current chain
+type Chainer interface {
+	ChainLength() int
+	// BreakChain resets the chain at the given count, the count itself is kept
+	BreakChain(count int)
+	// ChainBreaks are the encounters the chain broke at, splitting and
+	// merging phases moves them
+	ChainBreaks() []int
+	SetChainBreaks(breaks []int)
+}
+
+// chainCurves give the rolls of a single encounter at a given chain length,
+// the chain length is the number of encounters before this one in the chain
+var chainCurves = map[ProgressType]func(hunt *ChainHunt, chain int) float64{
+	SOS: func(hunt *ChainHunt, chain int) float64 {
+		rolls := 1 + hunt.charmRolls()
+		switch {
+		case chain > 30:
+			rolls += 12
+		case chain > 20:
+			rolls += 8
+		case chain > 10:
+			rolls += 4
+		}
+		return float64(rolls)
+	},
+	// the Poké Radar lowers the divisor of the shiny check for every patch
+	// in the chain up to 40, this is expressed in rolls at the normal odds
+	PokeRadar: func(hunt *ChainHunt, chain int) float64 {
+		divisor := float64(8200-200*min(chain, 40)) * hunt.Odds / 8192
+		chance := math.Ceil(65535/divisor) / 65536
+		rolls := math.Log(1-chance) / math.Log(1-1/hunt.Odds)
+		return rolls + float64(hunt.charmRolls())
+	},
+	ChainFishing: func(hunt *ChainHunt, chain int) float64 {
+		return float64(1 + hunt.charmRolls() + 2*min(chain, 20))
+	},
+	CatchCombo: func(hunt *ChainHunt, chain int) float64 {
+		rolls := 1 + hunt.charmRolls()
+		if hunt.HasLure {
+			rolls += 1
+		}
+		switch {
+		case chain > 30:
+			rolls += 11
+		case chain > 20:
+			rolls += 8
+		case chain > 10:
+			rolls += 4
+		}
+		return float64(rolls)
+	},
+}
+
+// ChainHunt models methods where the odds of every encounter depend on the
+// chain it is part of, the count is the number of encounters and breaking
+// the chain does not reset it
+type ChainHunt struct {
+	Type       ProgressType `json:"Method"`
+	Odds       float64
+	CharmRolls int
+	// counts at which the chain was broken in increasing order
+	Breaks []int
+	Count  int
+	Rolls  int
+
+	HasLure  bool
+	HasCharm bool
+	Progress float64
+}
+
+func NewChainHunt(type_ ProgressType, rules GameRules, count int, hasCharm bool) (self *ChainHunt) {
+	self = &ChainHunt{
+		Type:       type_,
+		Odds:       rules.Odds,
+		CharmRolls: rules.CharmRolls,
+		Breaks:     []int{},
+		HasCharm:   hasCharm,
+	}
+	self.SetRollsFromCount(count)
+	return
+}
+
+// the defaults are the values of the SOS battles saved before chains were
+// tracked, those only stored the rolls
+func unmarshalChainHunt(type_ ProgressType) func([]byte) (Progress, error) {
+	return unmarshalInto(func() *ChainHunt {
+		return &ChainHunt{Type: type_, Odds: 4096, CharmRolls: 2, Breaks: []int{}}
+	})
+}
+
+func (self *ChainHunt) charmRolls() int {
+	if self.HasCharm {
+		return self.CharmRolls
+	}
+	return 0
+}
+
+// lastBreak is the last count before the given count at which the chain broke
+func lastBreak(breaks []int, count int) (last int) {
+	for _, b := range breaks {
+		if b < count {
+			last = b
+		}
+	}
+	return
+}
+
+func (self *ChainHunt) SetRollsFromCount(count int) {
+	// breaks after the count were undone by decreasing it
+	for len(self.Breaks) > 0 && self.Breaks[len(self.Breaks)-1] > count {
+		self.Breaks = self.Breaks[:len(self.Breaks)-1]
+	}
+
+	self.Count = count
+	curve := chainCurves[self.Type]
+	rolls := 0.0
+	for encounter := 1; encounter <= count; encounter++ {
+		rolls += curve(self, encounter-1-lastBreak(self.Breaks, encounter))
+	}
+	self.Rolls = int(math.Round(rolls))
+	self.Progress = math.Pow(1-1/self.Odds, rolls)
+}
+
+func (self *ChainHunt) ChainLength() int {
+	if len(self.Breaks) == 0 {
+		return self.Count
+	}
+	return self.Count - self.Breaks[len(self.Breaks)-1]
+}
+
+func (self *ChainHunt) BreakChain(count int) {
+	if len(self.Breaks) > 0 && self.Breaks[len(self.Breaks)-1] == count {
+		return
+	}
+	self.Breaks = append(self.Breaks, count)
+	self.SetRollsFromCount(count)
+}
+
+func (self *ChainHunt) ChainBreaks() []int {
+	return append([]int{}, self.Breaks...)
+}
+
+func (self *ChainHunt) SetChainBreaks(breaks []int) {
+	self.Breaks = append([]int{}, breaks...)
+}
+
+func (self *ChainHunt) Charm() bool {
+	return self.HasCharm
+}
+
+func (self *ChainHunt) SetCharm(hasCharm bool) {
+	self.HasCharm = hasCharm
+}
+
+func (self *ChainHunt) GetProgress() float64 {
+	return self.Progress
+}
+
+func (self *ChainHunt) GetRolls() int {
+	return self.Rolls
+}
+
+func (self *ChainHunt) GetOdds() float64 {
+	return self.Odds
+}
+
+func (self *ChainHunt) GetType() ProgressType {
+	return self.Type
+}
+
+func (self *ChainHunt) Options() []Option {
+	if self.Type == CatchCombo {
+		return []Option{{"Lure", self.HasLure}}
+	}
+	return nil
+}
+
+func (self *ChainHunt) SetOption(name string, value interface{}) {
+	switch name {
+	case "Lure":
+		self.HasLure = value.(bool)
+	}
+}
+
+func (self *ChainHunt) MarshalJSON() (bytes []byte, err error) {
+	m := map[string]interface{}{}
+
+	m["type"] = "ChainHunt"
+	m["Method"] = self.Type
+	m["Odds"] = self.Odds
+	m["CharmRolls"] = self.CharmRolls
+	m["Breaks"] = self.Breaks
+	m["Count"] = self.Count
+	m["Rolls"] = self.Rolls
+	m["HasLure"] = self.HasLure
+	m["HasCharm"] = self.HasCharm
+	m["Progress"] = self.Progress
+	return json.Marshal(m)
+}
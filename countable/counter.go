@@ -3,22 +3,31 @@ package countable
 import (
 	"fmt"
 	"math"
+	"strings"
 	EventBus "tallyGo/eventBus"
 	"time"
 
-	"gonum.org/v1/gonum/stat/distuv"
+	"golang.org/x/exp/slices"
 )
 
 type Counter struct {
+	// ID stays the same for the life of the counter, groups refer to counters by it
+	ID           int
 	Name         string
 	Phases       []*Phase
 	ProgressType ProgressType
+	Game         Game
+	Target       Target
+	Keys         KeyBindings
+	Tags         []string
+	// ID of the counter this hunt continues, 0 when it is not linked
+	LinkedTo int
 
 	callbackChange map[string][]func()
 }
 
 func NewCounter(name string, _ int, progressType ProgressType) (counter *Counter) {
-	counter = &Counter{name, []*Phase{}, progressType, nil}
+	counter = &Counter{0, name, []*Phase{}, progressType, GameUnset, Target{}, KeyBindings{}, []string{}, 0, nil}
 	counter.NewPhase()
 	return
 }
@@ -39,10 +48,22 @@ func (self *Counter) NewPhase() *Phase {
 		0,
 		time.Duration(0),
 		nil,
+		self.Game,
+		nil,
+		1,
+		[]Step{},
 		false,
+		nil,
 	}
 
 	newPhase.SetProgressType(self.ProgressType)
+	if len(self.Phases) > 0 {
+		lastPhase := self.Phases[len(self.Phases)-1]
+		newPhase.SetCharm(lastPhase.HasCharm())
+		for _, option := range lastPhase.Options() {
+			newPhase.SetOption(option.Name, option.Value)
+		}
+	}
 	self.Phases = append(self.Phases, newPhase)
 
 	EventBus.GetGlobalBus().SendSignal(PhaseAdded, self, newPhase)
@@ -59,13 +80,52 @@ func (self *Counter) hasPhase(phase *Phase) bool {
 	return false
 }
 
-func (self *Counter) RemovePhase(phase *Phase) {
+func (self *Counter) phaseIdx(phase *Phase) int {
 	for idx, p := range self.Phases {
-		if p == phase && !p.IsCompleted {
-			self.Phases = append(self.Phases[:idx], self.Phases[idx+1:]...)
+		if p == phase {
+			return idx
 		}
 	}
+	return -1
+}
+
+// RemovePhase returns false when the phase is completed or the last one left
+func (self *Counter) RemovePhase(phase *Phase) bool {
+	if phase.IsCompleted {
+		return false
+	}
+	return self.detachPhase(phase)
+}
+
+// detachPhase removes completed phases as well, they are moved or merged
+// and not lost. A counter always keeps one phase
+func (self *Counter) detachPhase(phase *Phase) bool {
+	idx := self.phaseIdx(phase)
+	if idx == -1 || len(self.Phases) == 1 {
+		return false
+	}
+	self.Phases = append(self.Phases[:idx], self.Phases[idx+1:]...)
 	EventBus.GetGlobalBus().SendSignal(PhaseRemoved, self, phase)
+	return true
+}
+
+// InsertPhase puts a removed phase back at idx
+func (self *Counter) InsertPhase(idx int, phase *Phase) {
+	idx = max(min(idx, len(self.Phases)), 0)
+	self.Phases = append(self.Phases[:idx], append([]*Phase{phase}, self.Phases[idx:]...)...)
+	EventBus.GetGlobalBus().SendSignal(PhaseInserted, self, phase, idx)
+}
+
+// MovePhase changes the order of the phases, the phase ends up at idx
+func (self *Counter) MovePhase(phase *Phase, idx int) {
+	from := self.phaseIdx(phase)
+	if from < 0 {
+		return
+	}
+	self.Phases = append(self.Phases[:from], self.Phases[from+1:]...)
+	idx = max(min(idx, len(self.Phases)), 0)
+	self.Phases = append(self.Phases[:idx], append([]*Phase{phase}, self.Phases[idx:]...)...)
+	EventBus.GetGlobalBus().SendSignal(PhaseMoved, self, phase, idx)
 }
 
 func (self *Counter) GetChance() (chance float64) {
@@ -73,17 +133,57 @@ func (self *Counter) GetChance() (chance float64) {
 	return
 }
 
+// GetOdds returns the odds of the current phase
 func (self *Counter) GetOdds() (odds float64) {
-	switch self.ProgressType {
-	case OldOdds:
-		odds = 8192
-	case NewOdds:
-		odds = 4096
-	case SOS:
-		odds = 4096
+	return self.Phases[len(self.Phases)-1].GetOdds()
+}
+
+func (self *Counter) GetGame() Game {
+	return self.Game
+}
+
+func (self *Counter) SetGame(game Game) {
+	self.Game = game
+	for _, p := range self.Phases {
+		p.SetGame(game)
 	}
+	self.GetProgress()
+	EventBus.GetGlobalBus().SendSignal(TargetChanged, self)
+}
 
-	return
+func (self *Counter) GetTarget() Target {
+	return self.Target
+}
+
+func (self *Counter) SetTarget(target Target) {
+	self.Target = target
+	EventBus.GetGlobalBus().SendSignal(TargetChanged, self)
+}
+
+// Description summarizes what is hunted, for example
+// "Ralts ♀ - Route 102 | Gen 3 | Full Odds"
+func (self *Counter) Description() string {
+	parts := []string{}
+	if !self.Target.IsEmpty() {
+		parts = append(parts, self.Target.String())
+	}
+	if self.Game != GameUnset {
+		parts = append(parts, self.Game.String())
+	}
+	parts = append(parts, self.ProgressType.String())
+	return strings.Join(parts, " | ")
+}
+
+// SetTags stores the tags without surrounding spaces or duplicates
+func (self *Counter) SetTags(tags []string) {
+	self.Tags = []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(self.Tags, tag) {
+			self.Tags = append(self.Tags, tag)
+		}
+	}
+	EventBus.GetGlobalBus().SendSignal(TargetChanged, self)
 }
 
 func (self *Counter) GetRolls() (rolls int) {
@@ -100,6 +200,13 @@ func (self *Counter) GetCount() (count int) {
 	return
 }
 
+func (self *Counter) GetEncounters() (encounters int) {
+	for _, phase := range self.Phases {
+		encounters += phase.GetEncounters()
+	}
+	return
+}
+
 func (self *Counter) SetCount(num int) {
 	diff := num - self.GetCount()
 	self.Phases[len(self.Phases)-1].IncreaseBy(diff)
@@ -110,6 +217,19 @@ func (self *Counter) IncreaseBy(add int) {
 	self.GetProgress()
 }
 
+func (self *Counter) GetChain() int {
+	return self.Phases[len(self.Phases)-1].GetChain()
+}
+
+func (self *Counter) BreakChain() {
+	self.Phases[len(self.Phases)-1].BreakChain()
+	self.GetProgress()
+}
+
+func (self *Counter) LastStepTime() (time.Duration, bool) {
+	return self.Phases[len(self.Phases)-1].LastStepTime()
+}
+
 func (self *Counter) GetTime() (time time.Duration) {
 	for _, phase := range self.Phases {
 		time += phase.Time
@@ -118,37 +238,19 @@ func (self *Counter) GetTime() (time time.Duration) {
 }
 
 func (self *Counter) SetTime(time time.Duration) {
-	diff := self.GetTime() - time
-	self.Phases[len(self.Phases)-1].AddTime(diff)
+	diff := time - self.GetTime()
+	lastPhase := self.Phases[len(self.Phases)-1]
+	lastPhase.SetTime(lastPhase.Time + diff)
 }
 
 func (self *Counter) AddTime(time time.Duration) {
 	self.Phases[len(self.Phases)-1].AddTime(time)
 }
 
+// GetProgress returns the chance of having found fewer shinies than there
+// are phases, with the odds and rolls of every phase
 func (self *Counter) GetProgress() (progress float64) {
-	averageOdds := self.GetOdds()
-	rolls := self.GetRolls()
-	var completed int
-
-	switch self.ProgressType {
-	case SOS:
-		completed = 1
-	default:
-		completed = len(self.Phases)
-	}
-
-	for _, phase := range self.Phases {
-		phase.GetProgress()
-	}
-
-	binomial := distuv.Binomial{
-		N:   float64(rolls),
-		P:   1 / averageOdds,
-		Src: nil,
-	}
-
-	return binomial.CDF(float64(completed - 1))
+	return chanceBelow(self.Phases, len(self.Phases))
 }
 
 func (self *Counter) GetProgressType() ProgressType {
@@ -161,6 +263,7 @@ func (self *Counter) SetProgressType(type_ ProgressType) {
 		p.SetProgressType(type_)
 	}
 	self.GetProgress()
+	EventBus.GetGlobalBus().SendSignal(TargetChanged, self)
 }
 
 func (self *Counter) HasCharm() bool {
@@ -179,6 +282,22 @@ func (self *Counter) SetCharm(hasCharm bool) {
 	self.GetProgress()
 }
 
+// Options returns the settings of the current phase
+func (self *Counter) Options() []Option {
+	return self.Phases[len(self.Phases)-1].Options()
+}
+
+// SetOption only changes the current phase, new phases copy the settings of
+// the phase before them
+func (self *Counter) SetOption(name string, value interface{}) {
+	self.Phases[len(self.Phases)-1].SetOption(name, value)
+	self.GetProgress()
+}
+
+func (self *Counter) ValidateOption(name string, value interface{}) error {
+	return self.Phases[len(self.Phases)-1].ValidateOption(name, value)
+}
+
 func (self *Counter) IsCompleted() (isCompleted bool) {
 	for _, p := range self.Phases {
 		if !p.IsCompleted {
@@ -203,7 +322,7 @@ func (self *Counter) SetCompleted(isCompleted bool) {
 
 func (self *Counter) Deviation() (deviation float64) {
 	for _, p := range self.Phases {
-		deviation += (float64(p.GetCount()) / self.GetOdds())
+		deviation += (float64(p.GetEncounters()) / p.GetOdds())
 	}
 	return
 }
@@ -3,28 +3,77 @@ package countable
 import (
 	"log"
 	EventBus "tallyGo/eventBus"
-
-	"gonum.org/v1/gonum/stat/distuv"
 )
 
 type CounterList struct {
-	List   []*Counter
-	active []Countable
+	List []*Counter
+	// top level groups, counters not in any group are shown after them
+	Groups []*Group
+	// finished hunts hidden from the treeview, they still count for statistics
+	Archive []*Counter
+	active  []Countable
+	nextID  int
 }
 
-func NewCounterList(list []*Counter) (self *CounterList) {
-	self = &CounterList{list, nil}
+func NewCounterList(list []*Counter, groups []*Group, archive []*Counter) (self *CounterList) {
+	self = &CounterList{list, groups, archive, nil, 1}
+	// save files from before groups have no ids
+	for _, c := range self.all() {
+		self.nextID = max(self.nextID, c.ID+1)
+	}
+	for _, c := range self.all() {
+		if c.ID == 0 {
+			self.assignID(c)
+		}
+	}
+	for _, g := range self.Groups {
+		g.setList(self)
+	}
 	self.setupListeners()
 	return
 }
 
+// all returns the active and the archived counters
+func (self *CounterList) all() []*Counter {
+	return append(append([]*Counter{}, self.List...), self.Archive...)
+}
+
+func (self *CounterList) assignID(counter *Counter) {
+	counter.ID = self.nextID
+	self.nextID++
+}
+
 func (self *CounterList) setupListeners() {
 	EventBus.GetGlobalBus().Subscribe(RemoveCounter, func(args ...interface{}) {
-		self.RemoveCounter(args[0].(*Counter))
+		GetUndoStack().Do(NewRemoveCounterCommand(self, args[0].(*Counter)))
 	})
 
 	EventBus.GetGlobalBus().Subscribe(RemovePhase, func(args ...interface{}) {
-		self.RemovePhase(args[0].(*Phase))
+		phase := args[0].(*Phase)
+		if counter, ok := self.GetCounterOfPhase(phase); ok {
+			GetUndoStack().Do(NewRemovePhaseCommand(counter, phase))
+		}
+	})
+
+	EventBus.GetGlobalBus().Subscribe(MergePhase, func(args ...interface{}) {
+		phase := args[0].(*Phase)
+		if counter, ok := self.GetCounterOfPhase(phase); ok {
+			GetUndoStack().Do(NewMergePhasesCommand(counter, phase))
+		}
+	})
+
+	EventBus.GetGlobalBus().Subscribe(SplitPhase, func(args ...interface{}) {
+		phase := args[0].(*Phase)
+		if counter, ok := self.GetCounterOfPhase(phase); ok {
+			GetUndoStack().Do(NewSplitPhaseCommand(counter, phase, args[1].(int)))
+		}
+	})
+
+	EventBus.GetGlobalBus().Subscribe(FinishPhase, func(args ...interface{}) {
+		phase := args[0].(*Phase)
+		if counter, ok := self.GetCounterOfPhase(phase); ok {
+			GetUndoStack().Do(NewFinishCommand(counter, phase, args[1].(Result)))
+		}
 	})
 }
 
@@ -47,6 +96,7 @@ func (self *CounterList) SetActive(countables ...Countable) {
 
 func (self *CounterList) NewCounter() {
 	counter := NewCounter("Name...", 0, OldOdds)
+	self.assignID(counter)
 	self.List = append(self.List, counter)
 	EventBus.GetGlobalBus().SendSignal(CounterAdded, counter)
 }
@@ -60,12 +110,48 @@ func (self *CounterList) RemoveCounter(counter *Counter) {
 	}
 }
 
+// InsertCounter puts a removed counter back at idx
+func (self *CounterList) InsertCounter(idx int, counter *Counter) {
+	idx = max(min(idx, len(self.List)), 0)
+	self.List = append(self.List[:idx], append([]*Counter{counter}, self.List[idx:]...)...)
+	EventBus.GetGlobalBus().SendSignal(CounterInserted, counter, idx)
+}
+
+// MoveCounter changes the manual order, the counter ends up at idx
+func (self *CounterList) MoveCounter(counter *Counter, idx int) {
+	from, ok := self.GetIdx(counter)
+	if !ok {
+		log.Println("[WARN]\tTried to move a non existent Counter")
+		return
+	}
+	self.List = append(self.List[:from], self.List[from+1:]...)
+	idx = max(min(idx, len(self.List)), 0)
+	self.List = append(self.List[:idx], append([]*Counter{counter}, self.List[idx:]...)...)
+	EventBus.GetGlobalBus().SendSignal(CounterMoved, counter, idx)
+}
+
 func (self *CounterList) RemovePhase(phase *Phase) {
+	if c, ok := self.GetCounterOfPhase(phase); ok {
+		c.RemovePhase(phase)
+	}
+}
+
+func (self *CounterList) GetCounterOfPhase(phase *Phase) (*Counter, bool) {
 	for _, c := range self.List {
 		if c.hasPhase(phase) {
-			c.RemovePhase(phase)
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func (self *CounterList) GetCounterByID(id int) (*Counter, bool) {
+	for _, c := range self.List {
+		if c.ID == id {
+			return c, true
 		}
 	}
+	return nil, false
 }
 
 func (self *CounterList) GetIdx(counter *Counter) (int, bool) {
@@ -78,56 +164,143 @@ func (self *CounterList) GetIdx(counter *Counter) (int, bool) {
 }
 
 func (self *CounterList) Deviation() (deviation float64) {
-	for _, c := range self.List {
+	for _, c := range self.all() {
 		deviation += c.Deviation()
 	}
 
 	return
 }
 
+// Completed returns the number of shinies found including the archive, every completed phase ended
+// with one
 func (self *CounterList) Completed() (completed int) {
-	for _, c := range self.List {
-		switch c.GetProgressType() {
-		case SOS:
-			completed += 1
-		default:
-			completed += len(c.Phases)
+	for _, c := range self.all() {
+		for _, p := range c.Phases {
+			if p.IsCompleted {
+				completed += 1
+			}
 		}
 	}
 	return
 }
 
+func (self *CounterList) Phases() (phases []*Phase) {
+	for _, c := range self.all() {
+		phases = append(phases, c.Phases...)
+	}
+	return
+}
+
 func (self *CounterList) AverageOdds() (odds float64) {
-	for _, c := range self.List {
+	for _, c := range self.all() {
 		odds += c.GetOdds() * float64(c.GetRolls())
 	}
 	return odds / float64(self.TotalRolls())
 }
 
 func (self *CounterList) TotalCount() (count int) {
-	for _, c := range self.List {
+	for _, c := range self.all() {
 		count += c.GetCount()
 	}
 	return
 }
 
 func (self *CounterList) TotalRolls() (rolls int) {
-	for _, c := range self.List {
+	for _, c := range self.all() {
 		rolls += c.GetRolls()
 	}
 	return
 }
 
+// Luck returns the share of hunters that would have found fewer shinies in
+// the same hunts, 0.5 is average luck
 func (self *CounterList) Luck() float64 {
-	averageOdds := self.AverageOdds()
-	rolls := self.TotalRolls()
-	completed := self.Completed()
+	return luck(self.Phases(), self.Completed())
+}
 
-	binomial := distuv.Binomial{
-		N:   float64(rolls),
-		P:   1 / averageOdds,
-		Src: nil,
+// NewGroup adds an empty group to parent, a nil parent adds it at the top level
+func (self *CounterList) NewGroup(name string, parent *Group) *Group {
+	group := &Group{name, []*Group{}, []int{}, true, self}
+	if parent == nil {
+		self.Groups = append(self.Groups, group)
+	} else {
+		parent.Groups = append(parent.Groups, group)
+	}
+	EventBus.GetGlobalBus().SendSignal(GroupsChanged, self)
+	return group
+}
+
+// RemoveGroup deletes the group, its counters and subgroups move up to the
+// parent of the group
+func (self *CounterList) RemoveGroup(group *Group) {
+	parent, ok := self.GetParent(group)
+	if !ok {
+		log.Println("[WARN]\tTried to delete a non existent Group")
+		return
 	}
 
-	return binomial.CDF(float64(completed - 1))
+	groups := &self.Groups
+	if parent != nil {
+		groups = &parent.Groups
+		parent.CounterIDs = append(parent.CounterIDs, group.CounterIDs...)
+	}
+	for idx, g := range *groups {
+		if g == group {
+			*groups = append((*groups)[:idx], (*groups)[idx+1:]...)
+			break
+		}
+	}
+	*groups = append(*groups, group.Groups...)
+	EventBus.GetGlobalBus().SendSignal(GroupsChanged, self)
+}
+
+// GetParent returns the group containing group, nil for top level groups
+func (self *CounterList) GetParent(group *Group) (*Group, bool) {
+	var find func(parent *Group, groups []*Group) (*Group, bool)
+	find = func(parent *Group, groups []*Group) (*Group, bool) {
+		for _, g := range groups {
+			if g == group {
+				return parent, true
+			}
+			if p, ok := find(g, g.Groups); ok {
+				return p, true
+			}
+		}
+		return nil, false
+	}
+	return find(nil, self.Groups)
+}
+
+// AllGroups returns every group depth first
+func (self *CounterList) AllGroups() (groups []*Group) {
+	var walk func([]*Group)
+	walk = func(children []*Group) {
+		for _, g := range children {
+			groups = append(groups, g)
+			walk(g.Groups)
+		}
+	}
+	walk(self.Groups)
+	return
+}
+
+// GetGroupOf returns the group directly containing the counter
+func (self *CounterList) GetGroupOf(counter *Counter) (*Group, bool) {
+	for _, g := range self.AllGroups() {
+		if g.hasCounter(counter.ID) {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// MoveToGroup puts the counter into group, a nil group moves it to the top level
+func (self *CounterList) MoveToGroup(counter *Counter, group *Group) {
+	for _, g := range self.AllGroups() {
+		g.removeCounter(counter.ID)
+	}
+	if group != nil {
+		group.CounterIDs = append(group.CounterIDs, counter.ID)
+	}
+	EventBus.GetGlobalBus().SendSignal(GroupsChanged, self)
 }
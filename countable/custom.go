@@ -0,0 +1,177 @@
+package countable
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+)
+
+// variables a rolls formula can use, n is the encounter number in the phase
+// starting at 1 and charm is 1 when the shiny charm is owned
+var customFormulaVars = []string{"n", "charm", "charmrolls"}
+
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        Custom,
+		Codec:       "CustomOdds",
+		DisplayName: "Custom",
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewCustomOdds(rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalInto(func() *CustomOdds { return &CustomOdds{} }),
+	})
+}
+
+// CustomOdds lets the user pick the odds and optionally a formula for the
+// rolls of every encounter, for rom hacks and fan games
+type CustomOdds struct {
+	Odds       float64
+	CharmRolls int
+	// rolls per encounter, when empty every encounter rolls once plus the charm
+	Formula string
+	Rolls   int
+
+	HasCharm bool
+	Progress float64
+
+	formula *formula
+}
+
+func NewCustomOdds(rules GameRules, count int, hasCharm bool) (self *CustomOdds) {
+	self = &CustomOdds{
+		Odds:       rules.Odds,
+		CharmRolls: rules.CharmRolls,
+		HasCharm:   hasCharm,
+	}
+	self.SetRollsFromCount(count)
+	return
+}
+
+// SetFormula only changes the formula when it is valid
+func (self *CustomOdds) SetFormula(source string) (err error) {
+	if source == "" {
+		self.Formula, self.formula = "", nil
+		return
+	}
+
+	parsed, err := parseFormula(source, customFormulaVars)
+	if err != nil {
+		return
+	}
+	self.Formula, self.formula = source, parsed
+	return
+}
+
+// vars is reused for every encounter as the formula is evaluated a lot
+func (self *CustomOdds) encounterRolls(encounter int, vars map[string]float64) float64 {
+	charm := 0.0
+	if self.HasCharm {
+		charm = 1
+	}
+
+	if self.formula == nil {
+		return 1 + charm*float64(self.CharmRolls)
+	}
+
+	vars["n"] = float64(encounter)
+	vars["charm"] = charm
+	vars["charmrolls"] = float64(self.CharmRolls)
+	rolls := self.formula.eval(vars)
+	if math.IsNaN(rolls) || rolls < 0 {
+		return 0
+	}
+	return rolls
+}
+
+func (self *CustomOdds) SetRollsFromCount(count int) {
+	// the formula is not stored in the save file in parsed form
+	if self.formula == nil && self.Formula != "" {
+		if err := self.SetFormula(self.Formula); err != nil {
+			log.Println("[WARN]\tInvalid rolls formula in save file, Got Error: ", err)
+			self.Formula = ""
+		}
+	}
+
+	rolls := 0.0
+	vars := map[string]float64{}
+	for encounter := 1; encounter <= count; encounter++ {
+		rolls += self.encounterRolls(encounter, vars)
+	}
+	if math.IsInf(rolls, 0) {
+		rolls = math.MaxInt32
+	}
+	self.Rolls = int(math.Round(rolls))
+	self.Progress = math.Pow(1-1/self.Odds, rolls)
+}
+
+func (self *CustomOdds) Charm() bool {
+	return self.HasCharm
+}
+
+func (self *CustomOdds) SetCharm(hasCharm bool) {
+	self.HasCharm = hasCharm
+}
+
+func (self *CustomOdds) GetProgress() float64 {
+	return self.Progress
+}
+
+func (self *CustomOdds) GetRolls() int {
+	return self.Rolls
+}
+
+func (self *CustomOdds) GetOdds() float64 {
+	return self.Odds
+}
+
+func (self *CustomOdds) GetType() ProgressType {
+	return Custom
+}
+
+func (self *CustomOdds) Options() []Option {
+	return []Option{
+		{"Odds", int(self.Odds)},
+		{"Rolls Formula", self.Formula},
+	}
+}
+
+func (self *CustomOdds) ValidateOption(name string, value interface{}) (err error) {
+	switch name {
+	case "Odds":
+		if value.(int) <= 1 {
+			err = errors.New("the odds have to be higher than 1")
+		}
+	case "Rolls Formula":
+		if value.(string) != "" {
+			_, err = parseFormula(value.(string), customFormulaVars)
+		}
+	}
+	return
+}
+
+func (self *CustomOdds) SetOption(name string, value interface{}) {
+	switch name {
+	case "Odds":
+		if odds := value.(int); odds > 1 {
+			self.Odds = float64(odds)
+		}
+	case "Rolls Formula":
+		if err := self.SetFormula(value.(string)); err != nil {
+			log.Println("[WARN]\tInvalid rolls formula, Got Error: ", err)
+		}
+	}
+}
+
+func (self *CustomOdds) MarshalJSON() (bytes []byte, err error) {
+	m := map[string]interface{}{}
+
+	m["type"] = "CustomOdds"
+	m["Odds"] = self.Odds
+	m["CharmRolls"] = self.CharmRolls
+	m["Formula"] = self.Formula
+	m["Rolls"] = self.Rolls
+	m["HasCharm"] = self.HasCharm
+	m["Progress"] = self.Progress
+	return json.Marshal(m)
+}
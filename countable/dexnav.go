@@ -0,0 +1,176 @@
+package countable
+
+import (
+	"encoding/json"
+	"math"
+)
+
+const (
+	dexNavMaxSearchLevel = 999
+	// extra rolls when the search level check succeeds
+	dexNavSearchRolls = 4
+)
+
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        DexNav,
+		Codec:       "DexNav",
+		DisplayName: "DexNav",
+		Odds:        4096,
+		IsChain:     true,
+		New: func(_ GameRules, count int, hasCharm bool) Progress {
+			return NewDexNavSearch(0, count, hasCharm)
+		},
+		Unmarshal: unmarshalInto(func() *DexNavSearch { return &DexNavSearch{} }),
+	})
+}
+
+// DexNavSearch models the ORAS DexNav, the count is the length of the chain
+// unless it broke and every encounter raises the search level of the species
+// by one, broken chains included
+type DexNavSearch struct {
+	// search level at the start of the phase
+	SearchLevel int
+	// encounters the chain broke at
+	Breaks []int
+	Count  int
+	Rolls  int
+
+	HasCharm bool
+	Progress float64
+}
+
+func NewDexNavSearch(searchLevel int, count int, hasCharm bool) (self *DexNavSearch) {
+	self = &DexNavSearch{searchLevel, []int{}, 0, 0, hasCharm, 1.0}
+	self.SetRollsFromCount(count)
+	return
+}
+
+// dexNavSearchChance is the chance that the search level grants the extra rolls
+func dexNavSearchChance(searchLevel int) float64 {
+	level := min(searchLevel, dexNavMaxSearchLevel)
+	value := 0
+	if level > 200 {
+		value += level - 200
+		level = 200
+	}
+	if level > 100 {
+		value += (level - 100) * 2
+		level = 100
+	}
+	value += level * 6
+
+	return float64(value) / 10000
+}
+
+// dexNavChainRolls are the bonus rolls for reaching a chain of 50 or 100
+func dexNavChainRolls(chain int) int {
+	switch chain {
+	case 50:
+		return 5
+	case 100:
+		return 10
+	default:
+		return 0
+	}
+}
+
+func (self *DexNavSearch) SetRollsFromCount(count int) {
+	// breaks after the count were undone by decreasing it
+	for len(self.Breaks) > 0 && self.Breaks[len(self.Breaks)-1] > count {
+		self.Breaks = self.Breaks[:len(self.Breaks)-1]
+	}
+
+	self.Count = count
+	self.Progress = 1.0
+
+	fail := 4095.0 / 4096.0
+	expectedRolls := 0.0
+	for encounter := 1; encounter <= count; encounter++ {
+		rolls := 1 + dexNavChainRolls(encounter-lastBreak(self.Breaks, encounter))
+		if self.HasCharm {
+			rolls += 2
+		}
+		searchChance := dexNavSearchChance(self.SearchLevel + encounter - 1)
+
+		self.Progress *= (1-searchChance)*math.Pow(fail, float64(rolls)) +
+			searchChance*math.Pow(fail, float64(rolls+dexNavSearchRolls))
+		expectedRolls += float64(rolls) + searchChance*dexNavSearchRolls
+	}
+	self.Rolls = int(math.Round(expectedRolls))
+}
+
+func (self *DexNavSearch) ChainLength() int {
+	if len(self.Breaks) == 0 {
+		return self.Count
+	}
+	return self.Count - self.Breaks[len(self.Breaks)-1]
+}
+
+func (self *DexNavSearch) BreakChain(count int) {
+	if len(self.Breaks) > 0 && self.Breaks[len(self.Breaks)-1] == count {
+		return
+	}
+	self.Breaks = append(self.Breaks, count)
+	self.SetRollsFromCount(count)
+}
+
+func (self *DexNavSearch) ChainBreaks() []int {
+	return append([]int{}, self.Breaks...)
+}
+
+func (self *DexNavSearch) SetChainBreaks(breaks []int) {
+	self.Breaks = append([]int{}, breaks...)
+}
+
+func (self *DexNavSearch) Charm() bool {
+	return self.HasCharm
+}
+
+func (self *DexNavSearch) SetCharm(hasCharm bool) {
+	self.HasCharm = hasCharm
+}
+
+func (self *DexNavSearch) GetProgress() float64 {
+	return self.Progress
+}
+
+func (self *DexNavSearch) GetRolls() int {
+	return self.Rolls
+}
+
+func (self *DexNavSearch) GetOdds() float64 {
+	return 4096
+}
+
+func (self *DexNavSearch) GetType() ProgressType {
+	return DexNav
+}
+
+// the search level option is the current level as shown in game, so a new
+// phase continues where the previous one stopped
+func (self *DexNavSearch) Options() []Option {
+	return []Option{
+		{"Search Level", self.SearchLevel + self.Count},
+	}
+}
+
+func (self *DexNavSearch) SetOption(name string, value interface{}) {
+	switch name {
+	case "Search Level":
+		self.SearchLevel = max(value.(int)-self.Count, 0)
+	}
+}
+
+func (self *DexNavSearch) MarshalJSON() (bytes []byte, err error) {
+	m := map[string]interface{}{}
+
+	m["type"] = "DexNav"
+	m["SearchLevel"] = self.SearchLevel
+	m["Breaks"] = self.Breaks
+	m["Count"] = self.Count
+	m["Rolls"] = self.Rolls
+	m["HasCharm"] = self.HasCharm
+	m["Progress"] = self.Progress
+	return json.Marshal(m)
+}
@@ -0,0 +1,121 @@
+package countable
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// eggs in a full box
+const boxSize = 30
+
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        Breeding,
+		Codec:       "EggHunt",
+		DisplayName: "Breeding",
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewEggHunt(rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalInto(func() *EggHunt { return &EggHunt{} }),
+	})
+}
+
+// EggHunt models hatching eggs, the Masuda method and the shiny charm
+// both add rolls to every egg
+type EggHunt struct {
+	Odds        float64
+	CharmRolls  int
+	MasudaRolls int
+	Rolls       int
+
+	IsMasuda bool
+	// count full boxes of eggs instead of single eggs
+	CountBoxes bool
+	HasCharm   bool
+	Progress   float64
+}
+
+func NewEggHunt(rules GameRules, count int, hasCharm bool) (self *EggHunt) {
+	self = &EggHunt{
+		Odds:        rules.Odds,
+		CharmRolls:  rules.CharmRolls,
+		MasudaRolls: rules.MasudaRolls,
+		HasCharm:    hasCharm,
+	}
+	self.SetRollsFromCount(count)
+	return
+}
+
+func (self *EggHunt) SetRollsFromCount(count int) {
+	eggs := count
+	if self.CountBoxes {
+		eggs *= boxSize
+	}
+
+	rolls := 1
+	if self.HasCharm {
+		rolls += self.CharmRolls
+	}
+	if self.IsMasuda {
+		rolls += self.MasudaRolls
+	}
+
+	self.Rolls = eggs * rolls
+	self.GetProgress()
+}
+
+func (self *EggHunt) Charm() bool {
+	return self.HasCharm
+}
+
+func (self *EggHunt) SetCharm(hasCharm bool) {
+	self.HasCharm = hasCharm
+}
+
+func (self *EggHunt) GetProgress() float64 {
+	self.Progress = math.Pow(1-1/self.Odds, float64(self.Rolls))
+	return self.Progress
+}
+
+func (self *EggHunt) GetRolls() int {
+	return self.Rolls
+}
+
+func (self *EggHunt) GetOdds() float64 {
+	return self.Odds
+}
+
+func (self *EggHunt) GetType() ProgressType {
+	return Breeding
+}
+
+func (self *EggHunt) Options() []Option {
+	return []Option{
+		{"Masuda Method", self.IsMasuda},
+		{"Count Boxes", self.CountBoxes},
+	}
+}
+
+func (self *EggHunt) SetOption(name string, value interface{}) {
+	switch name {
+	case "Masuda Method":
+		self.IsMasuda = value.(bool)
+	case "Count Boxes":
+		self.CountBoxes = value.(bool)
+	}
+}
+
+func (self *EggHunt) MarshalJSON() (bytes []byte, err error) {
+	m := map[string]interface{}{}
+
+	m["type"] = "EggHunt"
+	m["Odds"] = self.Odds
+	m["CharmRolls"] = self.CharmRolls
+	m["MasudaRolls"] = self.MasudaRolls
+	m["Rolls"] = self.Rolls
+	m["IsMasuda"] = self.IsMasuda
+	m["CountBoxes"] = self.CountBoxes
+	m["HasCharm"] = self.HasCharm
+	m["Progress"] = self.Progress
+	return json.Marshal(m)
+}
@@ -0,0 +1,129 @@
+package countable
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// number of recent steps the encounter rate is measured over
+const rateWindow = 100
+
+// EncounterRate is the hunt time one encounter takes over the recent steps
+type EncounterRate struct {
+	Mean      time.Duration
+	Deviation time.Duration
+	Samples   int
+}
+
+// encounterRate measures the hunt time between increments, steps that
+// changed the count by more than one are spread over their encounters
+func encounterRate(history []Step, perPress int) (rate EncounterRate, ok bool) {
+	samples := []float64{}
+	next := -1
+	for idx := len(history) - 1; idx >= 0 && len(samples) < rateWindow; idx-- {
+		if history[idx].Diff <= 0 {
+			continue
+		}
+		if next != -1 {
+			step := history[next]
+			if elapsed := step.Time - history[idx].Time; elapsed > 0 {
+				samples = append(samples, float64(elapsed)/float64(step.Diff*max(perPress, 1)))
+			}
+		}
+		next = idx
+	}
+	if len(samples) < 2 {
+		return rate, false
+	}
+
+	mean := 0.0
+	for _, sample := range samples {
+		mean += sample
+	}
+	mean /= float64(len(samples))
+
+	variance := 0.0
+	for _, sample := range samples {
+		variance += (sample - mean) * (sample - mean)
+	}
+	variance /= float64(len(samples) - 1)
+
+	return EncounterRate{time.Duration(mean), time.Duration(math.Sqrt(variance)), len(samples)}, true
+}
+
+// Milestone is a point of the hunt worth projecting a time to
+type Milestone struct {
+	Name       string
+	Encounters int
+}
+
+// ETA is the projected hunt time until a milestone, Low and High bound the
+// 95% confidence band
+type ETA struct {
+	Milestone
+	Reached  bool
+	Estimate time.Duration
+	Low      time.Duration
+	High     time.Duration
+}
+
+// the band covers both the spread of single encounters and the uncertainty
+// of the measured mean
+func (self EncounterRate) project(encounters int) (estimate, low, high time.Duration) {
+	remaining := float64(encounters)
+	deviation := float64(self.Deviation)
+	variance := remaining*deviation*deviation + remaining*remaining*deviation*deviation/float64(self.Samples)
+	band := 1.96 * math.Sqrt(variance)
+
+	estimate = time.Duration(remaining * float64(self.Mean))
+	low = time.Duration(math.Max(float64(estimate)-band, 0))
+	high = time.Duration(float64(estimate) + band)
+	return
+}
+
+// Milestones are 1x odds and the statistic percentiles in encounters of the
+// phase
+func (self *Phase) Milestones() (milestones []Milestone) {
+	milestones = append(milestones, Milestone{"1x Odds", int(self.GetOdds())})
+	survival := self.survival()
+	for _, percentile := range StatPercentiles {
+		if encounters, ok := searchSurvival(survival, 1-percentile, 0); ok {
+			milestones = append(milestones, Milestone{fmt.Sprintf("%.0f%%", percentile*100), encounters})
+		}
+	}
+	return
+}
+
+// Projection returns the time until every milestone from the current rate
+func (self *Phase) Projection() ([]ETA, bool) {
+	return self.projection(0)
+}
+
+func (self *Phase) projection(offset int) (etas []ETA, ok bool) {
+	if self.IsCompleted {
+		return nil, false
+	}
+	rate, ok := encounterRate(self.History, self.EncountersPerPress)
+	if !ok {
+		return nil, false
+	}
+
+	current := self.GetEncounters()
+	for _, milestone := range self.Milestones() {
+		eta := ETA{Milestone: milestone, Reached: milestone.Encounters <= current}
+		if !eta.Reached {
+			eta.Estimate, eta.Low, eta.High = rate.project(milestone.Encounters - current)
+		}
+		eta.Encounters += offset
+		etas = append(etas, eta)
+	}
+	return etas, true
+}
+
+// Projection returns the milestones of the current phase in encounters of
+// the whole counter
+func (self *Counter) Projection() ([]ETA, bool) {
+	current := self.Phases[len(self.Phases)-1]
+	return current.projection(self.GetEncounters() - current.GetEncounters())
+}
@@ -0,0 +1,79 @@
+package countable
+
+import (
+	"strings"
+)
+
+// search fields a query term can be limited to, like "tag:kanto" or
+// "status:completed"
+var filterFields = []string{"name", "tag", "species", "game", "method", "status"}
+
+// Status is "archived" for archived counters, otherwise "completed" or "active"
+func (self *CounterList) Status(counter *Counter) string {
+	if _, ok := self.getArchiveIdx(counter); ok {
+		return "archived"
+	}
+	if counter.IsCompleted() {
+		return "completed"
+	}
+	return "active"
+}
+
+// Matches reports if the counter matches every term of the query, a term
+// without a field matches any field, case is ignored
+func (self *CounterList) Matches(counter *Counter, query string) bool {
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		field, value, hasField := strings.Cut(term, ":")
+		if !hasField || !isFilterField(field) {
+			field, value = "", term
+		}
+
+		matched := false
+		for _, candidate := range self.filterValues(counter, field) {
+			if strings.Contains(strings.ToLower(candidate), value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func isFilterField(field string) bool {
+	for _, f := range filterFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// filterValues returns the texts of a field, all fields for an empty field
+func (self *CounterList) filterValues(counter *Counter, field string) (values []string) {
+	if field == "" || field == "name" {
+		values = append(values, counter.Name)
+	}
+	if field == "" || field == "tag" {
+		values = append(values, counter.Tags...)
+	}
+	if field == "" || field == "species" {
+		values = append(values, counter.Target.Species, counter.Target.Form)
+		targets, _ := counter.TargetProgress()
+		for _, target := range targets {
+			values = append(values, target.Species)
+		}
+	}
+	if field == "" || field == "game" {
+		values = append(values, counter.Game.String())
+	}
+	if field == "" || field == "method" {
+		values = append(values, counter.ProgressType.String())
+	}
+	if field == "" || field == "status" {
+		values = append(values, self.Status(counter))
+	}
+	return
+}
@@ -0,0 +1,259 @@
+package countable
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formula is a parsed arithmetic expression used for user defined rolls,
+// it supports + - * / ^, parentheses, variables and a few math functions
+type formula struct {
+	eval func(vars map[string]float64) float64
+}
+
+var formulaFunctions = map[string]func(args ...float64) (float64, error){
+	"min": func(args ...float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("min needs at least one argument")
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, nil
+	},
+	"max": func(args ...float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("max needs at least one argument")
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	},
+	"floor": unaryFunction(math.Floor),
+	"ceil":  unaryFunction(math.Ceil),
+	"abs":   unaryFunction(math.Abs),
+	"sqrt":  unaryFunction(math.Sqrt),
+	"log":   unaryFunction(math.Log),
+}
+
+func unaryFunction(f func(float64) float64) func(args ...float64) (float64, error) {
+	return func(args ...float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+		return f(args[0]), nil
+	}
+}
+
+// parseFormula parses an expression, only the variables in vars may be used
+func parseFormula(source string, vars []string) (*formula, error) {
+	parser := &formulaParser{source: source, vars: vars}
+	parser.next()
+
+	eval, err := parser.expression()
+	if err != nil {
+		return nil, err
+	}
+	if parser.token != "" {
+		return nil, fmt.Errorf("unexpected %q at position %d", parser.token, parser.pos)
+	}
+	return &formula{eval}, nil
+}
+
+type formulaParser struct {
+	source string
+	vars   []string
+	pos    int
+	token  string
+}
+
+// next moves to the next token, tokens are numbers, names or single characters
+func (self *formulaParser) next() {
+	for self.pos < len(self.source) && unicode.IsSpace(rune(self.source[self.pos])) {
+		self.pos++
+	}
+	if self.pos >= len(self.source) {
+		self.token = ""
+		return
+	}
+
+	start := self.pos
+	char := rune(self.source[self.pos])
+	switch {
+	case unicode.IsDigit(char) || char == '.':
+		for self.pos < len(self.source) &&
+			(unicode.IsDigit(rune(self.source[self.pos])) || self.source[self.pos] == '.') {
+			self.pos++
+		}
+	case unicode.IsLetter(char):
+		for self.pos < len(self.source) &&
+			(unicode.IsLetter(rune(self.source[self.pos])) || unicode.IsDigit(rune(self.source[self.pos]))) {
+			self.pos++
+		}
+	default:
+		self.pos++
+	}
+	self.token = self.source[start:self.pos]
+}
+
+func (self *formulaParser) expect(token string) error {
+	if self.token != token {
+		return fmt.Errorf("expected %q at position %d", token, self.pos)
+	}
+	self.next()
+	return nil
+}
+
+// expression = term {("+" | "-") term}
+func (self *formulaParser) expression() (func(map[string]float64) float64, error) {
+	left, err := self.term()
+	if err != nil {
+		return nil, err
+	}
+	for self.token == "+" || self.token == "-" {
+		op := self.token
+		self.next()
+		right, err := self.term()
+		if err != nil {
+			return nil, err
+		}
+		lhs := left
+		if op == "+" {
+			left = func(vars map[string]float64) float64 { return lhs(vars) + right(vars) }
+		} else {
+			left = func(vars map[string]float64) float64 { return lhs(vars) - right(vars) }
+		}
+	}
+	return left, nil
+}
+
+// term = unary {("*" | "/") unary}
+func (self *formulaParser) term() (func(map[string]float64) float64, error) {
+	left, err := self.unary()
+	if err != nil {
+		return nil, err
+	}
+	for self.token == "*" || self.token == "/" {
+		op := self.token
+		self.next()
+		right, err := self.unary()
+		if err != nil {
+			return nil, err
+		}
+		lhs := left
+		if op == "*" {
+			left = func(vars map[string]float64) float64 { return lhs(vars) * right(vars) }
+		} else {
+			left = func(vars map[string]float64) float64 { return lhs(vars) / right(vars) }
+		}
+	}
+	return left, nil
+}
+
+// unary = "-" unary | power, so -2^2 is -(2^2)
+func (self *formulaParser) unary() (func(map[string]float64) float64, error) {
+	if self.token != "-" {
+		return self.power()
+	}
+	self.next()
+	value, err := self.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars map[string]float64) float64 { return -value(vars) }, nil
+}
+
+// power = primary ["^" unary], the exponent can be negative
+func (self *formulaParser) power() (func(map[string]float64) float64, error) {
+	base, err := self.primary()
+	if err != nil {
+		return nil, err
+	}
+	if self.token != "^" {
+		return base, nil
+	}
+	self.next()
+	exponent, err := self.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars map[string]float64) float64 { return math.Pow(base(vars), exponent(vars)) }, nil
+}
+
+// primary = number | variable | function "(" expression {"," expression} ")" | "(" expression ")"
+func (self *formulaParser) primary() (func(map[string]float64) float64, error) {
+	token := self.token
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of formula")
+	case token == "(":
+		self.next()
+		value, err := self.expression()
+		if err != nil {
+			return nil, err
+		}
+		return value, self.expect(")")
+	case unicode.IsDigit(rune(token[0])) || token[0] == '.':
+		number, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		self.next()
+		return func(map[string]float64) float64 { return number }, nil
+	case unicode.IsLetter(rune(token[0])):
+		self.next()
+		name := strings.ToLower(token)
+		if function, ok := formulaFunctions[name]; ok {
+			return self.call(name, function)
+		}
+		for _, v := range self.vars {
+			if v == name {
+				return func(vars map[string]float64) float64 { return vars[name] }, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown name %q, expected one of %v", token, self.vars)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", token, self.pos)
+	}
+}
+
+func (self *formulaParser) call(name string, function func(...float64) (float64, error)) (func(map[string]float64) float64, error) {
+	if err := self.expect("("); err != nil {
+		return nil, err
+	}
+
+	args := []func(map[string]float64) float64{}
+	for self.token != ")" {
+		arg, err := self.expression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if self.token == "," {
+			self.next()
+		} else if self.token != ")" {
+			return nil, fmt.Errorf("expected \",\" or \")\" at position %d", self.pos)
+		}
+	}
+	self.next()
+
+	// check the argument count once while parsing instead of on every evaluation
+	if _, err := function(make([]float64, len(args))...); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return func(vars map[string]float64) float64 {
+		values := make([]float64, len(args))
+		for idx, arg := range args {
+			values[idx] = arg(vars)
+		}
+		result, _ := function(values...)
+		return result
+	}, nil
+}
@@ -0,0 +1,91 @@
+package countable
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseFormula(t *testing.T) {
+	vars := map[string]float64{"n": 10, "charm": 1, "charmrolls": 2}
+	tests := []struct {
+		source string
+		want   float64
+	}{
+		{"1", 1},
+		{"1 + charm * charmrolls", 3},
+		{"2 * 3 + 4", 10},
+		{"2 * (3 + 4)", 14},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 ^ -1", 0.5},
+		{"2 * -3", -6},
+		{"--n", 10},
+		{".5 * n", 5},
+		{"min(n, 4, 6)", 4},
+		{"max(1, n / 2)", 5},
+		{"floor(n / 3) + ceil(0.2)", 4},
+		{"sqrt(abs(-16))", 4},
+		{"N + CHARM", 11},
+		{"log(1)", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			parsed, err := parseFormula(test.source, customFormulaVars)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := parsed.eval(vars); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("got %g, want %g", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFormulaErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"1 2",
+		"x + 1",
+		"min()",
+		"sqrt(1, 2)",
+		"floor 2",
+		"1..2",
+		"3 $ 4",
+	}
+
+	for _, source := range tests {
+		t.Run(source, func(t *testing.T) {
+			if _, err := parseFormula(source, customFormulaVars); err == nil {
+				t.Errorf("expected an error for %q", source)
+			}
+		})
+	}
+}
+
+func TestCustomOddsValidateOption(t *testing.T) {
+	progress := NewCustomOdds(GameUnset.Rules(), 0, false)
+	tests := []struct {
+		name  string
+		value interface{}
+		valid bool
+	}{
+		{"Odds", 4096, true},
+		{"Odds", 1, false},
+		{"Rolls Formula", "", true},
+		{"Rolls Formula", "1 + n / 100", true},
+		{"Rolls Formula", "1 +", false},
+	}
+
+	for _, test := range tests {
+		err := progress.ValidateOption(test.name, test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("%s %v: got error %v, want valid %t", test.name, test.value, err, test.valid)
+		}
+	}
+}
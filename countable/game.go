@@ -0,0 +1,129 @@
+package countable
+
+import "fmt"
+
+type Game int
+
+const (
+	// counters from before games were tracked, the odds come from the method
+	GameUnset Game = iota
+	Gen2
+	Gen3
+	Gen4
+	Gen5
+	Gen6
+	Gen7
+	LetsGo
+	SwSh
+	BDSP
+	PLA
+	SV
+)
+
+// GameRules are the shiny rules of a single game, methods take their base
+// odds and bonus rolls from here
+type GameRules struct {
+	Name string
+	Odds float64
+	// extra rolls from the shiny charm, 0 when the game has no charm
+	CharmRolls int
+	// extra rolls for breeding with a foreign parent, 0 when not available
+	MasudaRolls int
+
+	// Modifiers are the game specific settings of a hunt with their default
+	// values, ModifierRolls turns their current values into extra rolls
+	Modifiers     []Option
+	ModifierRolls func(values map[string]interface{}) int
+}
+
+var gameRules = map[Game]GameRules{
+	GameUnset: {"Unset", 4096, 2, 5, nil, nil},
+	Gen2:      {"Gen 2", 8192, 0, 0, nil, nil},
+	Gen3:      {"Gen 3", 8192, 0, 0, nil, nil},
+	Gen4:      {"Gen 4", 8192, 0, 4, nil, nil},
+	Gen5:      {"Gen 5", 8192, 2, 5, nil, nil},
+	Gen6:      {"Gen 6", 4096, 2, 5, nil, nil},
+	Gen7:      {"Gen 7", 4096, 2, 5, nil, nil},
+	LetsGo:    {"Let's Go", 4096, 2, 0, nil, nil},
+	SwSh:      {"Sword/Shield", 4096, 2, 5, nil, nil},
+	BDSP:      {"BDSP", 4096, 2, 5, nil, nil},
+	PLA: {
+		"Legends Arceus", 4096, 3, 0,
+		[]Option{
+			{"Research Level 10", false},
+			{"Perfect Research", false},
+			{"Mass Outbreak", false},
+			{"Massive Mass Outbreak", false},
+		},
+		plaModifierRolls,
+	},
+	SV: {
+		"Scarlet/Violet", 4096, 2, 5,
+		[]Option{
+			{"Outbreak Cleared", 0},
+			{"Sparkling Power", 0},
+		},
+		svModifierRolls,
+	},
+}
+
+// a perfect research entry includes the bonus of research level 10
+func plaModifierRolls(values map[string]interface{}) (rolls int) {
+	switch {
+	case values["Perfect Research"] == true:
+		rolls += 3
+	case values["Research Level 10"] == true:
+		rolls += 1
+	}
+
+	switch {
+	case values["Mass Outbreak"] == true:
+		rolls += 25
+	case values["Massive Mass Outbreak"] == true:
+		rolls += 12
+	}
+	return
+}
+
+// outbreaks count the number of pokemon defeated in the outbreak
+func svModifierRolls(values map[string]interface{}) (rolls int) {
+	switch cleared, _ := values["Outbreak Cleared"].(int); {
+	case cleared >= 60:
+		rolls += 2
+	case cleared >= 30:
+		rolls += 1
+	}
+
+	sparklingPower, _ := values["Sparkling Power"].(int)
+	rolls += max(min(sparklingPower, 3), 0)
+	return
+}
+
+func (self Game) String() string {
+	if rules, ok := gameRules[self]; ok {
+		return rules.Name
+	}
+	return fmt.Sprintf("Game(%d)", int(self))
+}
+
+func (self Game) Rules() GameRules {
+	return gameRules[self]
+}
+
+// Games returns every known game in release order
+func Games() (games []Game) {
+	for game := GameUnset; game <= SV; game++ {
+		games = append(games, game)
+	}
+	return
+}
+
+// RulesFor returns the rules a method uses in a game, methods with fixed
+// odds keep them regardless of the game
+func RulesFor(game Game, type_ ProgressType) (rules GameRules) {
+	rules = game.Rules()
+	if method, ok := GetMethod(type_); ok && method.Odds != 0 {
+		rules.Odds = method.Odds
+	}
+	return
+}
@@ -0,0 +1,219 @@
+package countable
+
+import (
+	"math"
+	EventBus "tallyGo/eventBus"
+	"time"
+)
+
+// Group is a folder for counters and other groups, it implements Countable
+// so the info box can show the totals of everything inside it
+type Group struct {
+	Name       string
+	Groups     []*Group
+	CounterIDs []int
+	IsExpanded bool
+
+	list *CounterList
+}
+
+func (self *Group) setList(list *CounterList) {
+	self.list = list
+	for _, g := range self.Groups {
+		g.setList(list)
+	}
+}
+
+// Counters returns the counters directly inside the group in the order of
+// the list, archived counters included so they still count for the totals.
+// Ids of removed counters are kept so undoing the removal puts them back
+// into the group
+func (self *Group) Counters() []*Counter {
+	if self.list == nil {
+		return nil
+	}
+	return self.filter(self.list.all())
+}
+
+// ListedCounters only returns the counters of the group that aren't archived
+func (self *Group) ListedCounters() []*Counter {
+	if self.list == nil {
+		return nil
+	}
+	return self.filter(self.list.List)
+}
+
+func (self *Group) filter(all []*Counter) (counters []*Counter) {
+	for _, counter := range all {
+		if self.hasCounter(counter.ID) {
+			counters = append(counters, counter)
+		}
+	}
+	return
+}
+
+// AllCounters returns the counters of the group and all its subgroups
+func (self *Group) AllCounters() (counters []*Counter) {
+	counters = self.Counters()
+	for _, g := range self.Groups {
+		counters = append(counters, g.AllCounters()...)
+	}
+	return
+}
+
+func (self *Group) Phases() (phases []*Phase) {
+	for _, c := range self.AllCounters() {
+		phases = append(phases, c.Phases...)
+	}
+	return
+}
+
+func (self *Group) hasCounter(id int) bool {
+	for _, i := range self.CounterIDs {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Group) removeCounter(id int) {
+	for idx, i := range self.CounterIDs {
+		if i == id {
+			self.CounterIDs = append(self.CounterIDs[:idx], self.CounterIDs[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (self *Group) GetName() string {
+	return self.Name
+}
+
+func (self *Group) SetName(name string) {
+	self.Name = name
+	EventBus.GetGlobalBus().SendSignal(NameChanged, self, self.Name)
+}
+
+func (self *Group) GetCount() (count int) {
+	for _, c := range self.AllCounters() {
+		count += c.GetCount()
+	}
+	return
+}
+
+func (self *Group) GetEncounters() (encounters int) {
+	for _, c := range self.AllCounters() {
+		encounters += c.GetEncounters()
+	}
+	return
+}
+
+// a group has no count of its own, the counters inside it have to be changed
+func (self *Group) SetCount(int) {}
+
+func (self *Group) IncreaseBy(int) {}
+
+func (self *Group) GetChain() int {
+	return 0
+}
+
+func (self *Group) BreakChain() {}
+
+func (self *Group) GetTime() (time time.Duration) {
+	for _, c := range self.AllCounters() {
+		time += c.GetTime()
+	}
+	return
+}
+
+func (self *Group) SetTime(time.Duration) {}
+
+func (self *Group) AddTime(time.Duration) {}
+
+func (self *Group) LastStepTime() (time.Duration, bool) {
+	return 0, false
+}
+
+func (self *Group) HasCharm() bool {
+	return false
+}
+
+func (self *Group) SetCharm(bool) {}
+
+func (self *Group) SetCompleted(bool) {}
+
+// Completed returns the number of shinies found in the group
+func (self *Group) Completed() (completed int) {
+	for _, p := range self.Phases() {
+		if p.IsCompleted {
+			completed += 1
+		}
+	}
+	return
+}
+
+// Luck returns the share of hunters that would have found fewer shinies in
+// the hunts of the group, 0.5 is average luck
+func (self *Group) Luck() float64 {
+	return luck(self.Phases(), self.Completed())
+}
+
+func (self *Group) EncountersAt(float64) (int, bool) {
+	return 0, false
+}
+
+func (self *Group) ExpectedRemaining() float64 {
+	return math.Inf(1)
+}
+
+// LuckPercentile is only known once the group has a shiny, like for a
+// single counter lower is luckier
+func (self *Group) LuckPercentile() (float64, bool) {
+	if self.Completed() == 0 {
+		return 0, false
+	}
+	return 1 - self.Luck(), true
+}
+
+func (self *Group) Projection() ([]ETA, bool) {
+	return nil, false
+}
+
+// GetOdds returns the odds of the group's rolls on average
+func (self *Group) GetOdds() float64 {
+	odds, rolls := 0.0, 0
+	for _, c := range self.AllCounters() {
+		odds += c.GetOdds() * float64(c.GetRolls())
+		rolls += c.GetRolls()
+	}
+	if rolls == 0 {
+		return 0
+	}
+	return odds / float64(rolls)
+}
+
+// GetProgress is the chance of not having found more shinies than phases
+// in the group, same as for a single counter
+func (self *Group) GetProgress() float64 {
+	phases := self.Phases()
+	if len(phases) == 0 {
+		return 1
+	}
+	return chanceBelow(phases, len(phases))
+}
+
+// GetProgressType returns the method of the counters when they all use the
+// same one
+func (self *Group) GetProgressType() ProgressType {
+	counters := self.AllCounters()
+	if len(counters) == 0 {
+		return OldOdds
+	}
+	for _, c := range counters[1:] {
+		if c.ProgressType != counters[0].ProgressType {
+			return OldOdds
+		}
+	}
+	return counters[0].ProgressType
+}
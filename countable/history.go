@@ -0,0 +1,48 @@
+package countable
+
+import "time"
+
+// Step is a single change of the count of a phase, the history of a phase
+// is only ever appended to
+type Step struct {
+	Diff int
+	// count of the phase after the step
+	Count int
+	Date  time.Time
+	// hunt time of the phase when the step happened
+	Time time.Duration
+}
+
+// lastStepTime is the hunt time between the last two increments, the first
+// increment is measured from the start of the phase
+func lastStepTime(history []Step) (duration time.Duration, ok bool) {
+	last := -1
+	for idx := len(history) - 1; idx >= 0; idx-- {
+		if history[idx].Diff <= 0 {
+			continue
+		}
+		if last == -1 {
+			last = idx
+			continue
+		}
+		return history[last].Time - history[idx].Time, true
+	}
+	if last != -1 {
+		return history[last].Time, true
+	}
+	return 0, false
+}
+
+// LastActivity is the date of the last change of the count or of the last
+// shiny found, the zero time for untouched counters
+func (self *Counter) LastActivity() (last time.Time) {
+	for _, phase := range self.Phases {
+		if len(phase.History) > 0 && phase.History[len(phase.History)-1].Date.After(last) {
+			last = phase.History[len(phase.History)-1].Date
+		}
+		if result, ok := phase.GetResult(); ok && result.Date.After(last) {
+			last = result.Date
+		}
+	}
+	return
+}
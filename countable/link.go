@@ -0,0 +1,169 @@
+package countable
+
+import (
+	"fmt"
+	"time"
+)
+
+// copyCounter returns a counter with the configuration of counter, with
+// phases it gets copies of all phases, otherwise one empty phase set up like
+// the current phase
+func copyCounter(counter *Counter, name string, withPhases bool) *Counter {
+	phases := []*Phase{}
+	if withPhases {
+		for _, phase := range counter.Phases {
+			clone := clonePhase(phase, phase.Name)
+			state := phase.state()
+			clone.Count, clone.Time = state.count, state.time
+			clone.History = append([]Step{}, state.history...)
+			clone.IsCompleted = state.isCompleted
+			if state.result != nil {
+				copied := *state.result
+				clone.Result = &copied
+			}
+			if chainer, ok := clone.Progress.(Chainer); ok {
+				chainer.SetChainBreaks(state.breaks)
+			}
+			// options like the DexNav search level depend on the count
+			for _, option := range phase.Options() {
+				clone.SetOption(option.Name, option.Value)
+			}
+			clone.UpdateProgress()
+			phases = append(phases, clone)
+		}
+	} else {
+		phases = append(phases, clonePhase(counter.Phases[len(counter.Phases)-1], "Phase_1"))
+	}
+
+	return &Counter{
+		0,
+		name,
+		phases,
+		counter.ProgressType,
+		counter.Game,
+		counter.Target,
+		counter.Keys,
+		append([]string{}, counter.Tags...),
+		0,
+		nil,
+	}
+}
+
+// DuplicateCounter adds a copy of the counter after it, in the same group
+func (self *CounterList) DuplicateCounter(counter *Counter, withPhases bool) *Counter {
+	duplicate := copyCounter(counter, counter.Name+" (copy)", withPhases)
+	self.addAfter(counter, duplicate)
+	return duplicate
+}
+
+// ContinueCounter starts a new hunt linked to the counter, for example to
+// keep hunting the same target in another game or with another method
+func (self *CounterList) ContinueCounter(counter *Counter) *Counter {
+	continued := copyCounter(counter, counter.Name, false)
+	continued.LinkedTo = counter.ID
+	self.addAfter(counter, continued)
+	return continued
+}
+
+func (self *CounterList) addAfter(counter, added *Counter) {
+	self.assignID(added)
+	if group, ok := self.GetGroupOf(counter); ok {
+		group.CounterIDs = append(group.CounterIDs, added.ID)
+	}
+	idx, ok := self.GetIdx(counter)
+	if !ok {
+		idx = len(self.List) - 1
+	}
+	GetUndoStack().Do(NewAddCounterCommand(self, added, idx+1))
+}
+
+// LinkedHunt is a hunt that was continued in other counters, the counters
+// are in the order they were continued in
+type LinkedHunt struct {
+	Counters []*Counter
+}
+
+func (self LinkedHunt) String() string {
+	names := ""
+	for idx, counter := range self.Counters {
+		if idx > 0 {
+			names += " → "
+		}
+		names += fmt.Sprintf("%s (%s)", counter.Name, counter.Game)
+	}
+	return names
+}
+
+func (self LinkedHunt) GetEncounters() (encounters int) {
+	for _, counter := range self.Counters {
+		encounters += counter.GetEncounters()
+	}
+	return
+}
+
+func (self LinkedHunt) GetTime() (time time.Duration) {
+	for _, counter := range self.Counters {
+		time += counter.GetTime()
+	}
+	return
+}
+
+func (self LinkedHunt) IsCompleted() bool {
+	for _, counter := range self.Counters {
+		if counter.IsCompleted() {
+			return true
+		}
+	}
+	return false
+}
+
+// linkRoot follows the links to the counter the hunt started with
+func (self *CounterList) linkRoot(counter *Counter) *Counter {
+	seen := map[*Counter]bool{}
+	for counter.LinkedTo != 0 && !seen[counter] {
+		seen[counter] = true
+		previous, ok := self.getAnyCounterByID(counter.LinkedTo)
+		if !ok {
+			break
+		}
+		counter = previous
+	}
+	return counter
+}
+
+func (self *CounterList) getAnyCounterByID(id int) (*Counter, bool) {
+	for _, c := range self.all() {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// GetLinkedHunt returns all counters linked to the counter, archived
+// counters included
+func (self *CounterList) GetLinkedHunt(counter *Counter) (hunt LinkedHunt) {
+	root := self.linkRoot(counter)
+	for _, c := range self.all() {
+		if self.linkRoot(c) == root {
+			hunt.Counters = append(hunt.Counters, c)
+		}
+	}
+	return
+}
+
+// LinkedHunts returns every hunt that was continued at least once
+func (self *CounterList) LinkedHunts() (hunts []LinkedHunt) {
+	seen := map[*Counter]bool{}
+	for _, c := range self.all() {
+		root := self.linkRoot(c)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		if hunt := self.GetLinkedHunt(root); len(hunt.Counters) > 1 {
+			hunts = append(hunts, hunt)
+		}
+	}
+	return
+}
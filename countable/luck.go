@@ -0,0 +1,70 @@
+package countable
+
+import "math"
+
+// phaseShinyChances returns the chances of seeing 0 up to limit-1 shinies in
+// the rolls of a phase. The rolls of a phase share their odds, chains and
+// modifiers only change how many rolls an encounter has, so the phase is a
+// binomial over the rolls its progress stands for
+func phaseShinyChances(phase *Phase, limit int) []float64 {
+	chances := make([]float64, limit)
+	p := 1 / phase.GetOdds()
+	survival := phase.GetProgress()
+	if survival >= 1 || p <= 0 || p >= 1 || math.IsNaN(p) {
+		chances[0] = 1
+		return chances
+	}
+	if survival <= 0 {
+		return chances
+	}
+
+	rolls := math.Log(survival) / math.Log1p(-p)
+	lgammaRolls, _ := math.Lgamma(rolls + 1)
+	for k := 0; k < limit && float64(k) <= rolls; k++ {
+		lgammaK, _ := math.Lgamma(float64(k) + 1)
+		lgammaRest, _ := math.Lgamma(rolls - float64(k) + 1)
+		chances[k] = math.Exp(lgammaRolls - lgammaK - lgammaRest +
+			float64(k)*math.Log(p) + (rolls-float64(k))*math.Log1p(-p))
+	}
+	return chances
+}
+
+// shinyDistribution is the Poisson-binomial distribution of the number of
+// shinies over all rolls of the phases, only the first limit values are kept
+func shinyDistribution(phases []*Phase, limit int) []float64 {
+	distribution := make([]float64, limit)
+	distribution[0] = 1
+
+	for _, phase := range phases {
+		chances := phaseShinyChances(phase, limit)
+		next := make([]float64, limit)
+		for found, chance := range distribution {
+			if chance == 0 {
+				continue
+			}
+			for k := 0; found+k < limit; k++ {
+				next[found+k] += chance * chances[k]
+			}
+		}
+		distribution = next
+	}
+	return distribution
+}
+
+// chanceBelow returns the chance of finding fewer than found shinies
+func chanceBelow(phases []*Phase, found int) (chance float64) {
+	for _, p := range shinyDistribution(phases, found+1)[:found] {
+		chance += p
+	}
+	return
+}
+
+// luck returns the share of hunters with the same phases that found fewer
+// shinies, counting half of those that found exactly as many
+func luck(phases []*Phase, found int) (luck float64) {
+	distribution := shinyDistribution(phases, found+1)
+	for _, p := range distribution[:found] {
+		luck += p
+	}
+	return luck + distribution[found]/2
+}
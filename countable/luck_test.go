@@ -0,0 +1,64 @@
+package countable
+
+import (
+	"math"
+	"testing"
+)
+
+func planPhase(type_ ProgressType, encounters int) *Phase {
+	phase := NewPlanPhase(Plan{Type: type_, Game: GameUnset})
+	phase.Count = encounters
+	phase.UpdateProgress()
+	return phase
+}
+
+// binomialBelow is the chance of fewer than found successes in rolls tries
+func binomialBelow(rolls int, p float64, found int) (chance float64) {
+	for k := 0; k < found; k++ {
+		lgammaRolls, _ := math.Lgamma(float64(rolls + 1))
+		lgammaK, _ := math.Lgamma(float64(k + 1))
+		lgammaRest, _ := math.Lgamma(float64(rolls - k + 1))
+		chance += math.Exp(lgammaRolls - lgammaK - lgammaRest +
+			float64(k)*math.Log(p) + float64(rolls-k)*math.Log1p(-p))
+	}
+	return
+}
+
+func TestChanceBelow(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases []*Phase
+		found  int
+		want   float64
+	}{
+		{"no shinies", []*Phase{planPhase(OldOdds, 5000)}, 0, 0},
+		{"no encounters", []*Phase{planPhase(OldOdds, 0)}, 1, 1},
+		{"one phase", []*Phase{planPhase(OldOdds, 5000)}, 1, math.Pow(1-1.0/8192, 5000)},
+		{
+			"two phases with the same odds",
+			[]*Phase{planPhase(NewOdds, 3000), planPhase(NewOdds, 6000)},
+			2,
+			binomialBelow(9000, 1.0/4096, 2),
+		},
+		{
+			"three phases with the same odds",
+			[]*Phase{planPhase(OldOdds, 8000), planPhase(OldOdds, 100), planPhase(OldOdds, 20000)},
+			3,
+			binomialBelow(28100, 1.0/8192, 3),
+		},
+		{
+			"different odds",
+			[]*Phase{planPhase(OldOdds, 8192), planPhase(NewOdds, 4096)},
+			1,
+			math.Pow(1-1.0/8192, 8192) * math.Pow(1-1.0/4096, 4096),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := chanceBelow(test.phases, test.found); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("got %.12f, want %.12f", got, test.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package countable
 
 import (
+	"fmt"
 	EventBus "tallyGo/eventBus"
 	"time"
 )
@@ -10,16 +11,29 @@ type Countable interface {
 	SetName(name string)
 
 	GetCount() int
+	GetEncounters() int
 	SetCount(num int)
 	IncreaseBy(add int)
 
+	GetChain() int
+	BreakChain()
+
 	GetTime() time.Duration
 	SetTime(time.Duration)
 	AddTime(time.Duration)
+	LastStepTime() (time.Duration, bool)
 
 	HasCharm() bool
 	SetCharm(bool)
 
+	SetCompleted(bool)
+	EncountersAt(percentile float64) (int, bool)
+	ExpectedRemaining() float64
+	LuckPercentile() (float64, bool)
+	Projection() ([]ETA, bool)
+
+	GetOdds() float64
+
 	GetProgress() float64
 	GetProgressType() ProgressType
 }
@@ -33,6 +47,8 @@ const (
 	TimeChanged = "TimeChanged"
 	// callback arguments (Countable)
 	CompletedStatus = "CompletedStatus"
+	// callback arguments (*Counter)
+	TargetChanged = "TargetChanged"
 
 	// callback arguments ([]Countable)
 	ListActiveChanged EventBus.Signal = "ListActiveChanged"
@@ -43,6 +59,20 @@ const (
 	RemoveCounter = "RemoveCounter"
 	// callback arguments (*Counter)
 	CounterRemoved = "CounterRemoved"
+	// callback arguments (*Counter, idx int)
+	CounterInserted = "CounterInserted"
+
+	// callback arguments (*Counter, idx int)
+	CounterMoved = "CounterMoved"
+	// callback arguments (*Counter)
+	CounterArchived = "CounterArchived"
+	// callback arguments (*Counter)
+	CounterRestored = "CounterRestored"
+
+	// callback arguments (*CounterList)
+	GroupsChanged = "GroupsChanged"
+	// callback arguments (*Counter)
+	SaveTemplate = "SaveTemplate"
 
 	// callback arguments (*Counter, newPhase)
 	PhaseAdded = "PhaseAdded"
@@ -50,6 +80,19 @@ const (
 	RemovePhase = "RemovePhase"
 	// callback arguments (*Counter, *Phase)
 	PhaseRemoved = "PhaseRemoved"
+	// callback arguments (*Counter, *Phase, idx int)
+	PhaseInserted = "PhaseInserted"
+	// callback arguments (*Counter, *Phase, idx int)
+	PhaseMoved = "PhaseMoved"
+	// callback arguments (*Phase)
+	MergePhase = "MergePhase"
+	// callback arguments (*Phase, encounters int)
+	SplitPhase = "SplitPhase"
+	// callback arguments (*Phase, Result)
+	FinishPhase = "FinishPhase"
+
+	// callback arguments (*UndoStack)
+	UndoStackChanged = "UndoStackChanged"
 )
 
 type ProgressType int
@@ -59,6 +102,13 @@ const (
 	NewOdds
 	SOS
 	DexNav
+	FullOdds
+	Breeding
+	PokeRadar
+	ChainFishing
+	CatchCombo
+	Custom
+	MultiTarget
 )
 
 type OldProgress struct {
@@ -73,22 +123,20 @@ func newProgress(type_ ProgressType) *OldProgress {
 	}
 }
 
-func (self ProgressType) HasPhases() bool {
-	switch self {
-	case OldOdds | NewOdds:
-		return true
-	default:
-		return false
+func (self ProgressType) String() string {
+	if method, ok := GetMethod(self); ok {
+		return method.DisplayName
 	}
+	return fmt.Sprintf("ProgressType(%d)", int(self))
+}
+
+func (self ProgressType) HasPhases() bool {
+	return !self.IsChain()
 }
 
 func (self ProgressType) IsChain() bool {
-	switch self {
-	case SOS | DexNav:
-		return true
-	default:
-		return false
-	}
+	method, ok := GetMethod(self)
+	return ok && method.IsChain
 }
 
 type callBackType int
@@ -0,0 +1,206 @@
+package countable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+)
+
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        MultiTarget,
+		Codec:       "MultiTargetOdds",
+		DisplayName: "Multi Target",
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewMultiTargetOdds(rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalInto(func() *MultiTargetOdds { return &MultiTargetOdds{} }),
+	})
+}
+
+// HuntTarget is one of the species of a multi target hunt
+type HuntTarget struct {
+	Species string
+	// percentage of the encounters that are this species
+	Slot float64
+	Odds float64
+}
+
+// HuntTargets is the value of the "Targets" option of multi target hunts
+type HuntTargets []HuntTarget
+
+// TargetProgress is the progress of a single target of a hunt
+type TargetProgress struct {
+	HuntTarget
+	// encounters that were expected to be this species
+	Encounters float64
+	// chance of not having found the target yet, like GetProgress
+	Progress float64
+}
+
+// TargetBreakdown is implemented by countables that hunt several targets
+type TargetBreakdown interface {
+	TargetProgress() ([]TargetProgress, bool)
+}
+
+// MultiTargetOdds hunts several species at once, every press is an encounter
+// with whichever species appeared, so each target only gets its slot's share
+// of the encounters
+type MultiTargetOdds struct {
+	Targets    HuntTargets
+	CharmRolls int
+	// extra rolls per encounter from the game modifiers
+	BonusRolls int
+	Rolls      int
+
+	HasCharm bool
+	Progress float64
+}
+
+func NewMultiTargetOdds(rules GameRules, count int, hasCharm bool) (self *MultiTargetOdds) {
+	self = &MultiTargetOdds{
+		Targets:    HuntTargets{{"", 100, rules.Odds}},
+		CharmRolls: rules.CharmRolls,
+		HasCharm:   hasCharm,
+	}
+	self.SetRollsFromCount(count)
+	return
+}
+
+func (self *MultiTargetOdds) SetRollsFromCount(count int) {
+	self.Rolls = count * self.rollsPerEncounter()
+	self.GetProgress()
+}
+
+func (self *MultiTargetOdds) rollsPerEncounter() int {
+	rolls := 1 + self.BonusRolls
+	if self.HasCharm {
+		rolls += self.CharmRolls
+	}
+	return rolls
+}
+
+func (self *MultiTargetOdds) SetBonusRolls(rolls int) {
+	self.BonusRolls = rolls
+}
+
+func (self *MultiTargetOdds) Charm() bool {
+	return self.HasCharm
+}
+
+func (self *MultiTargetOdds) SetCharm(hasCharm bool) {
+	self.HasCharm = hasCharm
+}
+
+// GetProgress returns the chance of having found none of the targets
+func (self *MultiTargetOdds) GetProgress() float64 {
+	self.Progress = 1
+	for _, target := range self.TargetProgress() {
+		self.Progress *= target.Progress
+	}
+	return self.Progress
+}
+
+// TargetProgress returns the share of the encounters and the progress of every
+// target, in the order they were added
+func (self *MultiTargetOdds) TargetProgress() (targets []TargetProgress) {
+	encounters := float64(self.Rolls) / float64(self.rollsPerEncounter())
+	for _, target := range self.Targets {
+		share := target.Slot / 100
+		targets = append(targets, TargetProgress{
+			target,
+			encounters * share,
+			math.Pow(1-1/target.Odds, float64(self.Rolls)*share),
+		})
+	}
+	return
+}
+
+func (self *MultiTargetOdds) GetRolls() int {
+	return self.Rolls
+}
+
+// GetOdds returns the odds of an encounter being a shiny of any target
+func (self *MultiTargetOdds) GetOdds() float64 {
+	chance := 0.0
+	for _, target := range self.Targets {
+		chance += target.Slot / 100 / target.Odds
+	}
+	return 1 / chance
+}
+
+func (self *MultiTargetOdds) GetType() ProgressType {
+	return MultiTarget
+}
+
+func (self *MultiTargetOdds) Options() []Option {
+	return []Option{
+		{"Targets", append(HuntTargets{}, self.Targets...)},
+	}
+}
+
+// SetOption keeps the old targets when the new ones can't be hunted, the
+// slots of all targets can't add up to more than every encounter
+func (self *MultiTargetOdds) SetOption(name string, value interface{}) {
+	if name != "Targets" {
+		return
+	}
+	if err := self.ValidateOption(name, value); err != nil {
+		log.Println("[WARN]\tCould not set the targets, Got Error: ", err)
+		return
+	}
+	self.Targets = append(HuntTargets{}, value.(HuntTargets)...)
+}
+
+// ValidateOption checks that every target has a slot and odds and that the
+// slots add up to at most 100%
+func (self *MultiTargetOdds) ValidateOption(name string, value interface{}) error {
+	if name != "Targets" {
+		return nil
+	}
+	targets := value.(HuntTargets)
+	if len(targets) == 0 {
+		return errors.New("at least one target is needed")
+	}
+	slots := 0.0
+	for _, target := range targets {
+		if target.Slot <= 0 || target.Odds <= 1 {
+			return fmt.Errorf("invalid slot or odds for target %q", target.Species)
+		}
+		slots += target.Slot
+	}
+	// the spin buttons round to a tenth of a percent
+	if slots > 100+1e-9 {
+		return fmt.Errorf("the slots add up to %.1f%%, at most 100%% is possible", slots)
+	}
+	return nil
+}
+
+func (self *MultiTargetOdds) MarshalJSON() (bytes []byte, err error) {
+	m := map[string]interface{}{}
+
+	m["type"] = "MultiTargetOdds"
+	m["Targets"] = self.Targets
+	m["CharmRolls"] = self.CharmRolls
+	m["BonusRolls"] = self.BonusRolls
+	m["Rolls"] = self.Rolls
+	m["HasCharm"] = self.HasCharm
+	m["Progress"] = self.Progress
+	return json.Marshal(m)
+}
+
+// TargetProgress returns the breakdown of multi target hunts
+func (self *Phase) TargetProgress() ([]TargetProgress, bool) {
+	if multi, ok := self.Progress.(*MultiTargetOdds); ok {
+		return multi.TargetProgress(), true
+	}
+	return nil, false
+}
+
+// TargetProgress returns the breakdown of the current phase, earlier phases
+// already ended with a shiny
+func (self *Counter) TargetProgress() ([]TargetProgress, bool) {
+	return self.Phases[len(self.Phases)-1].TargetProgress()
+}
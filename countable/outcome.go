@@ -0,0 +1,97 @@
+package countable
+
+import (
+	"fmt"
+	"time"
+)
+
+type Outcome int
+
+const (
+	OutcomeNone Outcome = iota
+	// the shiny that was hunted
+	OutcomeTarget
+	// a shiny of another species, the hunt goes on in a new phase
+	OutcomeOffTarget
+	// the shiny fled or was knocked out
+	OutcomeFail
+)
+
+func (self Outcome) String() string {
+	switch self {
+	case OutcomeNone:
+		return "None"
+	case OutcomeTarget:
+		return "Target"
+	case OutcomeOffTarget:
+		return "Off-Target"
+	case OutcomeFail:
+		return "Fail"
+	}
+	return fmt.Sprintf("Outcome(%d)", int(self))
+}
+
+// Outcomes returns the outcomes a phase can be finished with
+func Outcomes() []Outcome {
+	return []Outcome{OutcomeTarget, OutcomeOffTarget, OutcomeFail}
+}
+
+// Result records how a phase ended, nature, ball and notes are optional
+type Result struct {
+	Outcome   Outcome
+	Species   string
+	Encounter int
+	Date      time.Time
+
+	Nature string
+	Ball   string
+	Notes  string
+}
+
+func (self Result) String() string {
+	description := self.Outcome.String()
+	if self.Species != "" {
+		description += ": " + self.Species
+	}
+	return fmt.Sprintf("%s at encounter %d on %s", description, self.Encounter, self.Date.Format(time.DateOnly))
+}
+
+type finishCommand struct {
+	counter *Counter
+	phase   *Phase
+	result  Result
+
+	previous     *Result
+	wasCompleted bool
+	newPhase     *Phase
+}
+
+// NewFinishCommand completes the phase with the result, an off-target shiny
+// on the last phase opens a new phase for the ongoing hunt
+func NewFinishCommand(counter *Counter, phase *Phase, result Result) Command {
+	return &finishCommand{counter, phase, result, nil, false, nil}
+}
+
+func (self *finishCommand) Do() {
+	self.previous, self.wasCompleted = self.phase.Result, self.phase.IsCompleted
+	isLast := self.counter.phaseIdx(self.phase) == len(self.counter.Phases)-1
+
+	self.phase.Finish(self.result)
+
+	if self.result.Outcome != OutcomeOffTarget || !isLast {
+		return
+	}
+	if self.newPhase == nil {
+		self.newPhase = self.counter.NewPhase()
+	} else {
+		self.counter.InsertPhase(len(self.counter.Phases), self.newPhase)
+	}
+}
+
+func (self *finishCommand) Undo() {
+	if self.newPhase != nil {
+		self.counter.RemovePhase(self.newPhase)
+	}
+	self.phase.Result = self.previous
+	self.phase.SetCompleted(self.wasCompleted)
+}
@@ -12,8 +12,16 @@ type Phase struct {
 	Count    int
 	Time     time.Duration
 	Progress Progress
+	Game     Game
+	// values of the game modifiers by name, missing ones use the default
+	Modifiers map[string]interface{}
+	// pokemon seen with every press, for hordes, double battles and the like
+	EncountersPerPress int
+	History            []Step
 
 	IsCompleted bool
+	// how the phase ended, nil when it was completed without a result
+	Result *Result
 }
 
 func (self *Phase) GetName() (name string) {
@@ -33,7 +41,13 @@ func (self *Phase) GetCount() int {
 	return self.Count
 }
 
+// GetEncounters returns the number of pokemon seen, the count only holds the presses
+func (self *Phase) GetEncounters() int {
+	return self.Count * max(self.EncountersPerPress, 1)
+}
+
 func (self *Phase) SetCount(num int) {
+	self.addStep(num - self.Count)
 	self.Count = num
 	self.UpdateProgress()
 	EventBus.GetGlobalBus().SendSignal(CountChanged, self.Count)
@@ -43,11 +57,28 @@ func (self *Phase) IncreaseBy(add int) {
 	if self.IsCompleted {
 		return
 	}
+	self.addStep(add)
 	self.Count += add
 	self.UpdateProgress()
 	EventBus.GetGlobalBus().SendSignal(CountChanged, self.Count)
 }
 
+func (self *Phase) addStep(diff int) {
+	if diff == 0 {
+		return
+	}
+	self.History = append(self.History, Step{diff, self.Count + diff, time.Now(), self.Time})
+}
+
+func (self *Phase) GetHistory() []Step {
+	return self.History
+}
+
+// LastStepTime returns the hunt time the last encounter took
+func (self *Phase) LastStepTime() (time.Duration, bool) {
+	return lastStepTime(self.History)
+}
+
 func (self *Phase) GetTime() time.Duration {
 	return self.Time
 }
@@ -65,18 +96,45 @@ func (self *Phase) AddTime(time time.Duration) {
 	EventBus.GetGlobalBus().SendSignal(TimeChanged, self.Time)
 }
 
+// SetProgressType recreates the Progress, the options and chain breaks are
+// kept when the new method has them
 func (self *Phase) SetProgressType(type_ ProgressType) {
-	switch type_ {
-	case NewOdds:
-		self.Progress = NewDefaultOdds(4096, self.Count, false)
-	case SOS:
-		self.Progress = NewSOSBattle(self.Count, false)
-	default:
-		self.Progress = NewDefaultOdds(8192, self.Count, false)
+	method, ok := GetMethod(type_)
+	if !ok {
+		log.Printf("[WARN]\tUnregistered ProgressType %d, falling back to %s", type_, OldOdds)
+		method, _ = GetMethod(OldOdds)
+	}
+
+	hasCharm := self.Progress != nil && self.Progress.Charm()
+	var breaks []int
+	if chainer, ok := self.Progress.(Chainer); ok {
+		breaks = chainer.ChainBreaks()
+	}
+	options := self.Options()
+	self.Progress = method.New(RulesFor(self.Game, type_), self.Count, hasCharm)
+	if chainer, ok := self.Progress.(Chainer); ok && breaks != nil {
+		chainer.SetChainBreaks(breaks)
+	}
+	for _, option := range options {
+		self.SetOption(option.Name, option.Value)
 	}
 	self.UpdateProgress()
 }
 
+func (self *Phase) GetGame() Game {
+	return self.Game
+}
+
+// SetGame recreates the Progress with the rules of the new game
+func (self *Phase) SetGame(game Game) {
+	self.Game = game
+	self.SetProgressType(self.GetProgressType())
+}
+
+func (self *Phase) GetOdds() float64 {
+	return self.Progress.GetOdds()
+}
+
 func (self *Phase) GetProgress() float64 {
 	pr := self.Progress.GetProgress()
 	return pr
@@ -87,7 +145,97 @@ func (self *Phase) GetProgressType() ProgressType {
 }
 
 func (self *Phase) UpdateProgress() {
-	self.Progress.SetRollsFromCount(self.Count)
+	if modifiable, ok := self.Progress.(Modifiable); ok {
+		modifiable.SetBonusRolls(self.modifierRolls())
+	}
+	self.Progress.SetRollsFromCount(self.GetEncounters())
+}
+
+// modifiers returns the current values of every modifier of the game
+func (self *Phase) modifiers() (options []Option) {
+	for _, modifier := range self.Game.Rules().Modifiers {
+		if value, ok := self.Modifiers[modifier.Name]; ok {
+			modifier.Value = value
+		}
+		options = append(options, modifier)
+	}
+	return
+}
+
+func (self *Phase) isModifier(name string) bool {
+	for _, modifier := range self.Game.Rules().Modifiers {
+		if modifier.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *Phase) modifierRolls() int {
+	rules := self.Game.Rules()
+	if rules.ModifierRolls == nil {
+		return 0
+	}
+
+	values := map[string]interface{}{}
+	for _, modifier := range self.modifiers() {
+		values[modifier.Name] = modifier.Value
+	}
+	return rules.ModifierRolls(values)
+}
+
+// Options returns the encounters per press, the settings of the Progress
+// and the game modifiers when the Progress takes them
+func (self *Phase) Options() (options []Option) {
+	options = append(options, Option{"Encounters per Press", max(self.EncountersPerPress, 1)})
+	if configurable, ok := self.Progress.(Configurable); ok {
+		options = append(options, configurable.Options()...)
+	}
+	if _, ok := self.Progress.(Modifiable); ok {
+		options = append(options, self.modifiers()...)
+	}
+	return
+}
+
+func (self *Phase) SetOption(name string, value interface{}) {
+	if name == "Encounters per Press" {
+		self.EncountersPerPress = max(value.(int), 1)
+	} else if self.isModifier(name) {
+		if self.Modifiers == nil {
+			self.Modifiers = map[string]interface{}{}
+		}
+		self.Modifiers[name] = value
+	} else if configurable, ok := self.Progress.(Configurable); ok {
+		configurable.SetOption(name, value)
+	}
+	self.UpdateProgress()
+}
+
+// ValidateOption returns why the value can't be set, nil when it can
+func (self *Phase) ValidateOption(name string, value interface{}) error {
+	if validator, ok := self.Progress.(Validator); ok {
+		return validator.ValidateOption(name, value)
+	}
+	return nil
+}
+
+// GetChain returns the length of the current chain, 0 for methods without chains
+func (self *Phase) GetChain() int {
+	if chainer, ok := self.Progress.(Chainer); ok {
+		return chainer.ChainLength()
+	}
+	return 0
+}
+
+// BreakChain resets the chain without changing the count
+func (self *Phase) BreakChain() {
+	if self.IsCompleted {
+		return
+	}
+	if chainer, ok := self.Progress.(Chainer); ok {
+		chainer.BreakChain(self.GetEncounters())
+		EventBus.GetGlobalBus().SendSignal(CountChanged, self.Count)
+	}
 }
 
 func (self *Phase) HasCharm() bool {
@@ -99,6 +247,20 @@ func (self *Phase) SetCharm(hasCharm bool) {
 	self.UpdateProgress()
 }
 
+// Finish completes the phase with the result of the hunt
+func (self *Phase) Finish(result Result) {
+	self.Result = &result
+	self.SetCompleted(true)
+}
+
+// GetResult returns the result of a completed phase
+func (self *Phase) GetResult() (*Result, bool) {
+	if !self.IsCompleted || self.Result == nil {
+		return nil, false
+	}
+	return self.Result, true
+}
+
 func (self *Phase) SetCompleted(isCompleted bool) {
 	self.IsCompleted = isCompleted
 	EventBus.GetGlobalBus().SendSignal(CompletedStatus, self)
@@ -115,30 +277,47 @@ func (self *Phase) UnmarshalJSON(bytes []byte) (err error) {
 	self.Count = int(phaseObjMap["Count"].(float64))
 	self.Time = time.Duration(phaseObjMap["Time"].(float64))
 	self.IsCompleted = phaseObjMap["IsCompleted"].(bool)
+	self.EncountersPerPress = 1
+	if perPress, ok := phaseObjMap["EncountersPerPress"].(float64); ok {
+		self.EncountersPerPress = int(perPress)
+	}
+	if history, ok := objMap["History"]; ok && history != nil {
+		if err = json.Unmarshal(*history, &self.History); err != nil {
+			return
+		}
+	}
+	if result, ok := objMap["Result"]; ok && result != nil {
+		if err = json.Unmarshal(*result, &self.Result); err != nil {
+			return
+		}
+	}
+	if game, ok := phaseObjMap["Game"].(float64); ok {
+		self.Game = Game(game)
+	}
+	// json numbers decode as float64, the modifiers are ints
+	if modifiers, ok := phaseObjMap["Modifiers"].(map[string]interface{}); ok {
+		self.Modifiers = map[string]interface{}{}
+		for name, value := range modifiers {
+			if number, ok := value.(float64); ok {
+				value = int(number)
+			}
+			self.Modifiers[name] = value
+		}
+	}
 
 	var progressObjMap map[string]interface{}
 	err = json.Unmarshal(*objMap["Progress"], &progressObjMap)
 
-	switch progressObjMap["type"] {
-	case "DefaultOdds":
-		var progress Progress = &DefaultOdds{
-			Odds:     progressObjMap["Odds"].(float64),
-			Rolls:    int(progressObjMap["Rolls"].(float64)),
-			HasCharm: progressObjMap["HasCharm"].(bool),
-			Progress: progressObjMap["Progress"].(float64),
-		}
-		self.Progress = progress
-	case "SOSBattle":
-		var progress Progress = &SOSBattle{
-			Rolls:    int(progressObjMap["Rolls"].(float64)),
-			HasCharm: progressObjMap["HasCharm"].(bool),
-			Progress: progressObjMap["Progress"].(float64),
-		}
-		self.Progress = progress
-	default:
+	codec, _ := progressObjMap["type"].(string)
+	method, ok := GetMethodByCodec(codec)
+	if !ok {
 		log.SetFlags(log.Llongfile)
-		log.Fatal("Unhandled ProgressType")
+		log.Fatalf("Unhandled ProgressType %q", codec)
+	}
+	if self.Progress, err = method.Unmarshal(*objMap["Progress"]); err != nil {
+		return
 	}
+	self.UpdateProgress()
 
 	return
 }
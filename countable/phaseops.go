@@ -0,0 +1,272 @@
+package countable
+
+import (
+	"log"
+	EventBus "tallyGo/eventBus"
+	"time"
+)
+
+// phaseState is everything merging and splitting changes on a phase, breaks
+// are the encounters the chain broke at and nil for methods without chains
+type phaseState struct {
+	count       int
+	time        time.Duration
+	history     []Step
+	isCompleted bool
+	result      *Result
+	breaks      []int
+	perPress    int
+}
+
+func (self *Phase) state() phaseState {
+	var breaks []int
+	if chainer, ok := self.Progress.(Chainer); ok {
+		breaks = chainer.ChainBreaks()
+	}
+	return phaseState{self.Count, self.Time, self.History, self.IsCompleted, self.Result, breaks, max(self.EncountersPerPress, 1)}
+}
+
+// setState recomputes the progress for the new count and tells the ui
+func (self *Phase) setState(state phaseState) {
+	wasCompleted := self.IsCompleted
+	self.Count, self.Time, self.History = state.count, state.time, state.history
+	self.IsCompleted, self.Result = state.isCompleted, state.result
+	if chainer, ok := self.Progress.(Chainer); ok {
+		chainer.SetChainBreaks(state.breaks)
+	}
+	self.UpdateProgress()
+	EventBus.GetGlobalBus().SendSignal(CountChanged, self.Count)
+	EventBus.GetGlobalBus().SendSignal(TimeChanged, self.Time)
+	if wasCompleted != self.IsCompleted {
+		EventBus.GetGlobalBus().SendSignal(CompletedStatus, self)
+	}
+}
+
+// clonePhase returns a phase with the configuration of phase and no count
+func clonePhase(phase *Phase, name string) *Phase {
+	var modifiers map[string]interface{}
+	if phase.Modifiers != nil {
+		modifiers = map[string]interface{}{}
+		for name, value := range phase.Modifiers {
+			modifiers[name] = value
+		}
+	}
+
+	clone := &Phase{
+		name,
+		0,
+		time.Duration(0),
+		nil,
+		phase.Game,
+		modifiers,
+		phase.EncountersPerPress,
+		[]Step{},
+		false,
+		nil,
+	}
+	clone.SetProgressType(phase.GetProgressType())
+	clone.SetCharm(phase.HasCharm())
+	for _, option := range phase.Options() {
+		clone.SetOption(option.Name, option.Value)
+	}
+	return clone
+}
+
+// splitPhase divides the state at count, the hunt time of the split is
+// taken from the step of the history that reaches count. A step that jumps
+// over count is divided between both parts. Without history the time is
+// divided by the count. Chain breaks after the split move to the second part
+func splitPhase(state phaseState, count int) (first, second phaseState) {
+	splitTime := time.Duration(float64(state.time) * float64(count) / float64(state.count))
+	splitIdx := len(state.history)
+	previous := Step{}
+	for idx, step := range state.history {
+		if step.Count >= count {
+			// a step can jump over count when the count was set directly
+			share := float64(count-previous.Count) / float64(step.Count-previous.Count)
+			splitTime = previous.Time + time.Duration(share*float64(step.Time-previous.Time))
+			splitIdx = idx
+			break
+		}
+		previous = step
+	}
+
+	first = phaseState{count, splitTime, append([]Step{}, state.history[:splitIdx]...), false, nil, nil, state.perPress}
+	second = phaseState{state.count - count, max(state.time-splitTime, 0), []Step{}, state.isCompleted, state.result, nil, state.perPress}
+	if splitIdx < len(state.history) {
+		step := state.history[splitIdx]
+		first.history = append(first.history, Step{count - previous.Count, count, step.Date, splitTime})
+		if step.Count > count {
+			second.history = append(second.history, Step{step.Count - count, step.Count - count, step.Date, step.Time - splitTime})
+		}
+		splitIdx++
+	}
+	for _, step := range state.history[splitIdx:] {
+		second.history = append(second.history, Step{step.Diff, step.Count - count, step.Date, step.Time - splitTime})
+	}
+
+	if state.breaks != nil {
+		splitEncounter := count * state.perPress
+		first.breaks, second.breaks = []int{}, []int{}
+		for _, b := range state.breaks {
+			if b <= splitEncounter {
+				first.breaks = append(first.breaks, b)
+			} else {
+				second.breaks = append(second.breaks, b-splitEncounter)
+			}
+		}
+	}
+	return
+}
+
+// mergePhases appends the count, time and history of the second phase to
+// the first, the merged phase ends like the second one did. The chain of the
+// second phase started new, so the chain breaks where the first phase ended
+func mergePhases(first, second phaseState) (merged phaseState) {
+	merged = phaseState{
+		first.count + second.count,
+		first.time + second.time,
+		append([]Step{}, first.history...),
+		second.isCompleted,
+		second.result,
+		nil,
+		first.perPress,
+	}
+	for _, step := range second.history {
+		merged.history = append(merged.history, Step{step.Diff, step.Count + first.count, step.Date, step.Time + first.time})
+	}
+
+	if first.breaks != nil {
+		end := first.count * first.perPress
+		merged.breaks = append([]int{}, first.breaks...)
+		if end > 0 && (len(merged.breaks) == 0 || merged.breaks[len(merged.breaks)-1] != end) {
+			merged.breaks = append(merged.breaks, end)
+		}
+		for _, b := range second.breaks {
+			if b > 0 {
+				merged.breaks = append(merged.breaks, b+end)
+			}
+		}
+	}
+	return
+}
+
+type splitPhaseCommand struct {
+	counter    *Counter
+	phase      *Phase
+	encounters int
+	previous   phaseState
+	rest       *Phase
+}
+
+// NewSplitPhaseCommand splits the phase after the press that reaches
+// encounters, the phase keeps the first part and a new phase after it gets
+// the rest
+func NewSplitPhaseCommand(counter *Counter, phase *Phase, encounters int) Command {
+	return &splitPhaseCommand{counter, phase, encounters, phaseState{}, nil}
+}
+
+func (self *splitPhaseCommand) Do() {
+	count := self.encounters / max(self.phase.EncountersPerPress, 1)
+	if count <= 0 || count >= self.phase.Count {
+		self.rest = nil
+		return
+	}
+	self.previous = self.phase.state()
+	first, second := splitPhase(self.previous, count)
+	self.phase.setState(first)
+	// options like the DexNav search level depend on the count of the phase
+	if self.rest == nil {
+		self.rest = clonePhase(self.phase, self.phase.Name+" (split)")
+	}
+	self.rest.setState(second)
+	self.counter.InsertPhase(self.counter.phaseIdx(self.phase)+1, self.rest)
+}
+
+func (self *splitPhaseCommand) Undo() {
+	if self.rest == nil || !self.counter.detachPhase(self.rest) {
+		return
+	}
+	self.phase.setState(self.previous)
+}
+
+type mergePhasesCommand struct {
+	counter  *Counter
+	phase    *Phase
+	next     *Phase
+	previous phaseState
+}
+
+// NewMergePhasesCommand merges the phase with the phase after it, completed
+// phases are not merged as the shiny they ended with would be lost
+func NewMergePhasesCommand(counter *Counter, phase *Phase) Command {
+	return &mergePhasesCommand{counter, phase, nil, phaseState{}}
+}
+
+// canMerge is true for phases with the same method, game and encounters per
+// press, the merged phase keeps the setup of the first one
+func canMerge(first, second *Phase) bool {
+	return first.GetProgressType() == second.GetProgressType() &&
+		first.Game == second.Game &&
+		max(first.EncountersPerPress, 1) == max(second.EncountersPerPress, 1)
+}
+
+func (self *mergePhasesCommand) Do() {
+	idx := self.counter.phaseIdx(self.phase)
+	if idx == -1 || idx+1 >= len(self.counter.Phases) || self.phase.IsCompleted {
+		self.next = nil
+		return
+	}
+	if !canMerge(self.phase, self.counter.Phases[idx+1]) {
+		log.Println("[WARN]\tOnly phases with the same method, game and encounters per press can be merged")
+		self.next = nil
+		return
+	}
+	self.next = self.counter.Phases[idx+1]
+	self.previous = self.phase.state()
+	self.counter.detachPhase(self.next)
+	self.phase.setState(mergePhases(self.previous, self.next.state()))
+}
+
+func (self *mergePhasesCommand) Undo() {
+	if self.next == nil {
+		return
+	}
+	self.phase.setState(self.previous)
+	self.counter.InsertPhase(self.counter.phaseIdx(self.phase)+1, self.next)
+}
+
+type movePhaseToCounterCommand struct {
+	from  *Counter
+	to    *Counter
+	phase *Phase
+	idx   int
+	moved bool
+}
+
+// NewMovePhaseToCounterCommand moves the phase to the end of another counter,
+// the phase keeps its own method and game. Completed phases go before the
+// phase the counter is counting
+func NewMovePhaseToCounterCommand(from, to *Counter, phase *Phase) Command {
+	return &movePhaseToCounterCommand{from, to, phase, 0, false}
+}
+
+func (self *movePhaseToCounterCommand) Do() {
+	self.idx = self.from.phaseIdx(self.phase)
+	self.moved = self.from != self.to && self.from.detachPhase(self.phase)
+	if self.moved {
+		idx := len(self.to.Phases)
+		if self.phase.IsCompleted && idx > 0 && !self.to.Phases[idx-1].IsCompleted {
+			idx--
+		}
+		self.to.InsertPhase(idx, self.phase)
+		self.phase.UpdateProgress()
+	}
+}
+
+func (self *movePhaseToCounterCommand) Undo() {
+	if self.moved && self.to.detachPhase(self.phase) {
+		self.from.InsertPhase(self.idx, self.phase)
+		self.phase.UpdateProgress()
+	}
+}
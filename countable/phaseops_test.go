@@ -0,0 +1,222 @@
+package countable
+
+import (
+	"reflect"
+	EventBus "tallyGo/eventBus"
+	"testing"
+	"time"
+)
+
+func TestSplitPhase(t *testing.T) {
+	history := []Step{
+		{1, 10, time.Time{}, 10 * time.Second},
+		{10, 20, time.Time{}, 30 * time.Second},
+		{5, 25, time.Time{}, 40 * time.Second},
+	}
+	result := &Result{}
+
+	tests := []struct {
+		name          string
+		state         phaseState
+		count         int
+		first, second phaseState
+	}{
+		{
+			"without history",
+			phaseState{100, 100 * time.Second, nil, true, result, nil, 1},
+			25,
+			phaseState{25, 25 * time.Second, []Step{}, false, nil, nil, 1},
+			phaseState{75, 75 * time.Second, []Step{}, true, result, nil, 1},
+		},
+		{
+			"at a step",
+			phaseState{25, 40 * time.Second, history, false, nil, nil, 1},
+			20,
+			phaseState{20, 30 * time.Second, history[:2], false, nil, nil, 1},
+			phaseState{5, 10 * time.Second, []Step{{5, 5, time.Time{}, 10 * time.Second}}, false, nil, nil, 1},
+		},
+		{
+			"inside a step",
+			phaseState{25, 40 * time.Second, history, false, nil, nil, 1},
+			15,
+			phaseState{15, 20 * time.Second, []Step{history[0], {5, 15, time.Time{}, 20 * time.Second}}, false, nil, nil, 1},
+			phaseState{
+				10,
+				20 * time.Second,
+				[]Step{{5, 5, time.Time{}, 10 * time.Second}, {5, 10, time.Time{}, 20 * time.Second}},
+				false,
+				nil,
+				nil,
+				1,
+			},
+		},
+		{
+			"chain breaks",
+			phaseState{100, 100 * time.Second, nil, false, nil, []int{40, 100, 150}, 2},
+			50,
+			phaseState{50, 50 * time.Second, []Step{}, false, nil, []int{40, 100}, 2},
+			phaseState{50, 50 * time.Second, []Step{}, false, nil, []int{50}, 2},
+		},
+		{
+			"no chain breaks after the split",
+			phaseState{100, 100 * time.Second, nil, false, nil, []int{10}, 1},
+			50,
+			phaseState{50, 50 * time.Second, []Step{}, false, nil, []int{10}, 1},
+			phaseState{50, 50 * time.Second, []Step{}, false, nil, []int{}, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, second := splitPhase(test.state, test.count)
+			if !reflect.DeepEqual(first, test.first) {
+				t.Errorf("first got %+v, want %+v", first, test.first)
+			}
+			if !reflect.DeepEqual(second, test.second) {
+				t.Errorf("second got %+v, want %+v", second, test.second)
+			}
+		})
+	}
+}
+
+func TestMergePhases(t *testing.T) {
+	result := &Result{}
+
+	tests := []struct {
+		name          string
+		first, second phaseState
+		want          phaseState
+	}{
+		{
+			"counts and history",
+			phaseState{10, 10 * time.Second, []Step{{10, 10, time.Time{}, 10 * time.Second}}, false, nil, nil, 1},
+			phaseState{5, 20 * time.Second, []Step{{5, 5, time.Time{}, 20 * time.Second}}, true, result, nil, 1},
+			phaseState{
+				15,
+				30 * time.Second,
+				[]Step{{10, 10, time.Time{}, 10 * time.Second}, {5, 15, time.Time{}, 30 * time.Second}},
+				true,
+				result,
+				nil,
+				1,
+			},
+		},
+		{
+			"chain breaks",
+			phaseState{50, 0, nil, false, nil, []int{40}, 2},
+			phaseState{20, 0, nil, false, nil, []int{10}, 2},
+			phaseState{70, 0, []Step{}, false, nil, []int{40, 100, 110}, 2},
+		},
+		{
+			"first phase ended with a break",
+			phaseState{50, 0, nil, false, nil, []int{50}, 1},
+			phaseState{20, 0, nil, false, nil, []int{}, 1},
+			phaseState{70, 0, []Step{}, false, nil, []int{50}, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mergePhases(test.first, test.second); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// merging a split chain hunt breaks the chain where it was split, undoing
+// restores the chain it had
+func TestSplitMergeChain(t *testing.T) {
+	EventBus.InitBus()
+	phase := planPhase(SOS, 20)
+	phase.Progress.(Chainer).BreakChain(20)
+	phase.Count = 60
+	phase.UpdateProgress()
+	previous := phase.state()
+	rolls := phase.Progress.(*ChainHunt).Rolls
+
+	first, second := splitPhase(previous, 40)
+	phase.setState(mergePhases(first, second))
+	if got := phase.Progress.(*ChainHunt).Breaks; !reflect.DeepEqual(got, []int{20, 40}) {
+		t.Errorf("breaks got %v, want [20 40]", got)
+	}
+
+	phase.setState(previous)
+	if got := phase.Progress.(*ChainHunt).Breaks; !reflect.DeepEqual(got, []int{20}) {
+		t.Errorf("breaks after undo got %v, want [20]", got)
+	}
+	if got := phase.Progress.(*ChainHunt).Rolls; got != rolls {
+		t.Errorf("rolls got %d, want %d", got, rolls)
+	}
+}
+
+func TestMergePhasesCommand(t *testing.T) {
+	EventBus.InitBus()
+	tests := []struct {
+		name   string
+		setup  func(next *Phase)
+		merged bool
+	}{
+		{"same setup", func(*Phase) {}, true},
+		{"other method", func(next *Phase) { next.SetProgressType(NewOdds) }, false},
+		{"other game", func(next *Phase) { next.SetGame(GameUnset + 1) }, false},
+		{"other encounters per press", func(next *Phase) { next.SetOption("Encounters per Press", 5) }, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counter := NewCounter("counter", 0, OldOdds)
+			counter.Phases[0].Count = 10
+			next := counter.NewPhase()
+			next.Count = 20
+			test.setup(next)
+
+			NewMergePhasesCommand(counter, counter.Phases[0]).Do()
+			if merged := len(counter.Phases) == 1; merged != test.merged {
+				t.Fatalf("merged got %v, want %v", merged, test.merged)
+			}
+			if test.merged && counter.Phases[0].Count != 30 {
+				t.Errorf("count got %d, want 30", counter.Phases[0].Count)
+			}
+		})
+	}
+}
+
+// the split phase continues at the search level the first part ended at
+func TestSplitDexNavSearchLevel(t *testing.T) {
+	EventBus.InitBus()
+	counter := NewCounter("counter", 0, DexNav)
+	phase := counter.Phases[0]
+	phase.SetOption("Search Level", 10)
+	phase.SetCount(30)
+
+	NewSplitPhaseCommand(counter, phase, 20).Do()
+	rest := counter.Phases[1]
+	if got := rest.Options()[1].Value; got != 40 {
+		t.Errorf("search level after the split got %v, want 40", got)
+	}
+	if got := phase.Options()[1].Value; got != 30 {
+		t.Errorf("search level before the split got %v, want 30", got)
+	}
+}
+
+// moved completed phases don't take the place of the phase being counted
+func TestMoveCompletedPhase(t *testing.T) {
+	EventBus.InitBus()
+	from := NewCounter("from", 0, OldOdds)
+	moved := from.Phases[0]
+	moved.Count, moved.IsCompleted = 10, true
+	from.NewPhase()
+	to := NewCounter("to", 0, OldOdds)
+	counting := to.Phases[0]
+
+	command := NewMovePhaseToCounterCommand(from, to, moved)
+	command.Do()
+	if !reflect.DeepEqual(to.Phases, []*Phase{moved, counting}) {
+		t.Errorf("phases of the counter got %v, want the moved phase first", to.Phases)
+	}
+	command.Undo()
+	if len(to.Phases) != 1 || from.Phases[0] != moved {
+		t.Errorf("undo did not move the phase back")
+	}
+}
@@ -6,38 +6,120 @@ import (
 	"math"
 )
 
+func init() {
+	RegisterMethod(ProgressMethod{
+		Type:        OldOdds,
+		Codec:       "DefaultOdds",
+		DisplayName: "Old Odds",
+		Odds:        8192,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewDefaultOdds(OldOdds, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalDefaultOdds,
+	})
+	RegisterMethod(ProgressMethod{
+		Type:        NewOdds,
+		Codec:       "DefaultOdds",
+		DisplayName: "New Odds",
+		Odds:        4096,
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewDefaultOdds(NewOdds, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalDefaultOdds,
+	})
+	RegisterMethod(ProgressMethod{
+		Type:        FullOdds,
+		Codec:       "DefaultOdds",
+		DisplayName: "Full Odds",
+		New: func(rules GameRules, count int, hasCharm bool) Progress {
+			return NewDefaultOdds(FullOdds, rules, count, hasCharm)
+		},
+		Unmarshal: unmarshalDefaultOdds,
+	})
+}
+
 type Progress interface {
 	SetRollsFromCount(count int)
 	Charm() bool
 	SetCharm(hasCharm bool)
 	GetProgress() float64
 	GetRolls() int
+	GetOdds() float64
 	GetType() ProgressType
 	MarshalJSON() (bytes []byte, err error)
 }
 
+// Option is a setting of a Progress besides the shiny charm
+type Option struct {
+	Name  string
+	Value interface{}
+}
+
+// Configurable is implemented by Progress types that have settings the
+// edit dialog should show, values are int, string or bool
+type Configurable interface {
+	Options() []Option
+	SetOption(name string, value interface{})
+}
+
+// Validator is implemented by Configurable types with option values that can
+// be rejected, the edit dialog checks the values before setting them
+type Validator interface {
+	ValidateOption(name string, value interface{}) error
+}
+
+// Modifiable is implemented by Progress types that take the extra rolls of
+// the game modifiers
+type Modifiable interface {
+	SetBonusRolls(rolls int)
+}
+
 type DefaultOdds struct {
-	Odds  float64
-	Rolls int
+	// stored as "Method" as json keys are matched case insensitive against "type"
+	Type       ProgressType `json:"Method"`
+	Odds       float64
+	CharmRolls int
+	// extra rolls per encounter from the game modifiers
+	BonusRolls int
+	Rolls      int
 
 	HasCharm bool
 	Progress float64
 }
 
-func NewDefaultOdds(odds float64, count int, hasCharm bool) (self *DefaultOdds) {
-	self = &DefaultOdds{odds, 0, hasCharm, 0.0}
+func NewDefaultOdds(type_ ProgressType, rules GameRules, count int, hasCharm bool) (self *DefaultOdds) {
+	self = &DefaultOdds{type_, rules.Odds, rules.CharmRolls, 0, 0, hasCharm, 0.0}
 	self.SetRollsFromCount(count)
 	return
 }
 
+// saves from before the game rules only stored the odds, for those the type
+// is guessed from the odds and the charm gives the usual 2 extra rolls
+func unmarshalDefaultOdds(bytes []byte) (Progress, error) {
+	progress := &DefaultOdds{Type: -1, CharmRolls: 2}
+	err := json.Unmarshal(bytes, progress)
+	if progress.Type == -1 {
+		progress.Type = NewOdds
+		if progress.Odds == 8192 {
+			progress.Type = OldOdds
+		}
+	}
+	return progress, err
+}
+
 func (self *DefaultOdds) SetRollsFromCount(count int) {
+	rolls := 1 + self.BonusRolls
 	if self.HasCharm {
-		count *= 3
+		rolls += self.CharmRolls
 	}
-	self.Rolls = count
+	self.Rolls = count * rolls
 	self.GetProgress()
 }
 
+func (self *DefaultOdds) SetBonusRolls(rolls int) {
+	self.BonusRolls = rolls
+}
+
 func (self *DefaultOdds) Charm() bool {
 	return self.HasCharm
 }
@@ -55,12 +137,12 @@ func (self *DefaultOdds) GetRolls() int {
 	return self.Rolls
 }
 
+func (self *DefaultOdds) GetOdds() float64 {
+	return self.Odds
+}
+
 func (self *DefaultOdds) GetType() ProgressType {
-	if self.Odds == 8192 {
-		return OldOdds
-	} else {
-		return NewOdds
-	}
+	return self.Type
 }
 
 func (self *DefaultOdds) MarshalJSON() (bytes []byte, err error) {
@@ -71,73 +153,10 @@ func (self *DefaultOdds) MarshalJSON() (bytes []byte, err error) {
 	}
 
 	m["type"] = "DefaultOdds"
+	m["Method"] = self.Type
 	m["Odds"] = self.Odds
-	m["Rolls"] = self.Rolls
-	m["HasCharm"] = self.HasCharm
-	m["Progress"] = self.Progress
-	return json.Marshal(m)
-}
-
-type SOSBattle struct {
-	Rolls int
-
-	HasCharm bool
-	Progress float64
-}
-
-func NewSOSBattle(count int, hasCharm bool) (self *SOSBattle) {
-	self = &SOSBattle{0, hasCharm, 0.0}
-	self.SetRollsFromCount(count)
-	return
-}
-
-func (self *SOSBattle) SetRollsFromCount(count int) {
-	self.Rolls = 0
-	for i := 0; i <= count; i++ {
-		switch {
-		case i > 30:
-			self.Rolls += 13
-		case i > 20:
-			self.Rolls += 9
-		case i > 10:
-			self.Rolls += 5
-		default:
-			self.Rolls += 1
-		}
-	}
-
-	if self.HasCharm {
-		self.Rolls += count * 2
-	}
-
-	self.GetProgress()
-}
-
-func (self *SOSBattle) Charm() bool {
-	return self.HasCharm
-}
-
-func (self *SOSBattle) SetCharm(hasCharm bool) {
-	self.HasCharm = hasCharm
-}
-
-func (self *SOSBattle) GetProgress() float64 {
-	self.Progress = math.Pow(4095.0/4096.0, float64(self.Rolls))
-	return self.Progress
-}
-
-func (self *SOSBattle) GetRolls() int {
-	return self.Rolls
-}
-
-func (self *SOSBattle) GetType() ProgressType {
-	return SOS
-}
-
-func (self *SOSBattle) MarshalJSON() (bytes []byte, err error) {
-	m := map[string]interface{}{}
-
-	m["type"] = "SOSBattle"
+	m["CharmRolls"] = self.CharmRolls
+	m["BonusRolls"] = self.BonusRolls
 	m["Rolls"] = self.Rolls
 	m["HasCharm"] = self.HasCharm
 	m["Progress"] = self.Progress
@@ -0,0 +1,74 @@
+package countable
+
+import (
+	"encoding/json"
+	"log"
+
+	"golang.org/x/exp/slices"
+)
+
+// ProgressMethod describes a hunting method, every part of the app that needs
+// to know about a method (save file, edit dialog, info widgets) looks it up here
+type ProgressMethod struct {
+	Type ProgressType
+	// name stored in the "type" field of a Progress in the save file,
+	// more than one method can share a codec
+	Codec string
+	// codecs of older save files that decode with this method
+	Aliases     []string
+	DisplayName string
+	// fixed odds of the method, 0 when the odds come from the game
+	Odds float64
+	// chain methods count a single completion per counter instead of one per phase
+	IsChain bool
+
+	New       func(rules GameRules, count int, hasCharm bool) Progress
+	Unmarshal func(bytes []byte) (Progress, error)
+}
+
+var methods = map[ProgressType]*ProgressMethod{}
+
+// RegisterMethod makes a hunting method available to the rest of the app,
+// registering the same ProgressType twice is a programming error
+func RegisterMethod(method ProgressMethod) {
+	if _, ok := methods[method.Type]; ok {
+		log.SetFlags(log.Llongfile)
+		log.Fatalf("ProgressType %d registered twice", method.Type)
+	}
+	methods[method.Type] = &method
+}
+
+func GetMethod(type_ ProgressType) (method *ProgressMethod, ok bool) {
+	method, ok = methods[type_]
+	return
+}
+
+func GetMethodByCodec(codec string) (*ProgressMethod, bool) {
+	for _, method := range Methods() {
+		if method.Codec == codec || slices.Contains(method.Aliases, codec) {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+// Methods returns every registered method ordered by ProgressType
+func Methods() (list []*ProgressMethod) {
+	for _, method := range methods {
+		list = append(list, method)
+	}
+	slices.SortFunc(list, func(a, b *ProgressMethod) int {
+		return int(a.Type) - int(b.Type)
+	})
+	return
+}
+
+// unmarshalInto returns an Unmarshal function that decodes the save data
+// into a fresh value created by newEmpty
+func unmarshalInto[T Progress](newEmpty func() T) func([]byte) (Progress, error) {
+	return func(bytes []byte) (Progress, error) {
+		progress := newEmpty()
+		err := json.Unmarshal(bytes, progress)
+		return progress, err
+	}
+}
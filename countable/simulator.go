@@ -0,0 +1,145 @@
+package countable
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// points the survival of a plan is tabulated at, runs are interpolated
+// between them
+const simulationTableSize = 512
+
+// Plan is a hunt setup for the simulator, Options are set like the options
+// of a phase so they can hold method options, modifiers and the encounters
+// per press
+type Plan struct {
+	Name         string
+	Type         ProgressType
+	Game         Game
+	HasCharm     bool
+	Options      []Option
+	TimePerPress time.Duration
+}
+
+// NewPlanPhase creates a detached phase with the setup of the plan, it is
+// not part of any counter and sends no signals while setting up
+func NewPlanPhase(plan Plan) (phase *Phase) {
+	phase = &Phase{
+		plan.Name,
+		0,
+		time.Duration(0),
+		nil,
+		plan.Game,
+		nil,
+		1,
+		[]Step{},
+		false,
+		nil,
+	}
+	phase.SetProgressType(plan.Type)
+	phase.SetCharm(plan.HasCharm)
+	for _, option := range plan.Options {
+		phase.SetOption(option.Name, option.Value)
+	}
+	return
+}
+
+// SimulationResult holds the encounters and hunt times every run took to
+// find the shiny, both sorted from fastest to slowest
+type SimulationResult struct {
+	Plan       Plan
+	Encounters []int
+	Times      []time.Duration
+}
+
+// Simulate hunts the plan runs times, the same seed always gives the same
+// result. Every run draws the encounter of the shiny from the survival of
+// the plan's Progress, so any registered method can be simulated
+func Simulate(plan Plan, runs int, seed int64) (result SimulationResult) {
+	result.Plan = plan
+	phase := NewPlanPhase(plan)
+	perPress := max(phase.EncountersPerPress, 1)
+
+	table, step, ok := survivalTable(phase.survival())
+	random := rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		encounters := maxProjectedEncounters
+		if ok {
+			encounters = sampleEncounters(table, step, random.Float64())
+		}
+		presses := (encounters + perPress - 1) / perPress
+
+		result.Encounters = append(result.Encounters, encounters)
+		result.Times = append(result.Times, time.Duration(presses)*plan.TimePerPress)
+	}
+
+	sort.Ints(result.Encounters)
+	sort.Slice(result.Times, func(i, j int) bool { return result.Times[i] < result.Times[j] })
+	return
+}
+
+// survivalTable tabulates the log of the survival until nearly every hunter
+// found the shiny
+func survivalTable(survival func(int) float64) (table []float64, step float64, ok bool) {
+	end, ok := searchSurvival(survival, 1e-6, 0)
+	if !ok {
+		return nil, 0, false
+	}
+
+	step = math.Max(float64(end)/simulationTableSize, 1)
+	for encounters := 0.0; encounters < float64(end)+step; encounters += step {
+		table = append(table, math.Log(survival(int(math.Round(encounters)))))
+	}
+	return table, step, true
+}
+
+// sampleEncounters finds the encounter where the survival drops below the
+// drawn chance, an encounter n is the shiny when S(n-1) > chance >= S(n)
+func sampleEncounters(table []float64, step float64, chance float64) int {
+	target := math.Log(chance)
+	idx := sort.Search(len(table), func(i int) bool { return table[i] <= target })
+	if idx == len(table) {
+		return int(math.Round(float64(len(table)-1) * step))
+	}
+	if idx == 0 {
+		return 1
+	}
+
+	// log survival is close to linear between two points of the table
+	before, after := table[idx-1], table[idx]
+	fraction := 1.0
+	if before != after {
+		fraction = (before - target) / (before - after)
+	}
+	encounters := (float64(idx-1) + fraction) * step
+	return max(int(math.Ceil(encounters)), 1)
+}
+
+func (self SimulationResult) TimeAt(percentile float64) time.Duration {
+	if len(self.Times) == 0 {
+		return 0
+	}
+	idx := min(int(percentile*float64(len(self.Times))), len(self.Times)-1)
+	return self.Times[idx]
+}
+
+func (self SimulationResult) EncountersAt(percentile float64) int {
+	if len(self.Encounters) == 0 {
+		return 0
+	}
+	idx := min(int(percentile*float64(len(self.Encounters))), len(self.Encounters)-1)
+	return self.Encounters[idx]
+}
+
+func (self SimulationResult) MeanTime() time.Duration {
+	if len(self.Times) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, time := range self.Times {
+		total += float64(time)
+	}
+	return time.Duration(total / float64(len(self.Times)))
+}
@@ -0,0 +1,74 @@
+package countable
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSimulateSameSeed(t *testing.T) {
+	plan := Plan{"Old Odds", OldOdds, GameUnset, false, nil, time.Second}
+	first := Simulate(plan, 1000, 42)
+	second := Simulate(plan, 1000, 42)
+	if !slices.Equal(first.Encounters, second.Encounters) {
+		t.Error("the same seed gave different encounters")
+	}
+	if !slices.Equal(first.Times, second.Times) {
+		t.Error("the same seed gave different times")
+	}
+
+	other := Simulate(plan, 1000, 43)
+	if slices.Equal(first.Encounters, other.Encounters) {
+		t.Error("different seeds gave the same encounters")
+	}
+}
+
+func TestSimulateMedian(t *testing.T) {
+	tests := []struct {
+		name string
+		plan Plan
+		// median encounters of a hunt with fixed odds, ln(2) times the odds
+		median float64
+	}{
+		{"old odds", Plan{"Old Odds", OldOdds, GameUnset, false, nil, time.Second}, 5678},
+		{"new odds", Plan{"New Odds", NewOdds, GameUnset, false, nil, time.Second}, 2839},
+		{"hordes", Plan{"Hordes", OldOdds, GameUnset, false, []Option{{"Encounters per Press", 5}}, time.Second}, 5678},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := Simulate(test.plan, 20000, 1)
+			median := float64(result.EncountersAt(.5))
+			if math.Abs(median-test.median) > test.median*.03 {
+				t.Errorf("median %.0f encounters, want about %.0f", median, test.median)
+			}
+		})
+	}
+}
+
+func TestSimulateTimes(t *testing.T) {
+	plan := Plan{"Hordes", NewOdds, GameUnset, false, []Option{{"Encounters per Press", 5}}, time.Second}
+	result := Simulate(plan, 100, 7)
+	for idx, encounters := range result.Encounters {
+		presses := (encounters + 4) / 5
+		if result.Times[idx] != time.Duration(presses)*time.Second {
+			t.Fatalf("%d encounters took %s, want %d presses", encounters, result.Times[idx], presses)
+		}
+	}
+}
+
+func TestSimulateEveryMethod(t *testing.T) {
+	for _, method := range Methods() {
+		t.Run(method.DisplayName, func(t *testing.T) {
+			result := Simulate(Plan{method.DisplayName, method.Type, GameUnset, false, nil, time.Second}, 200, 3)
+			if len(result.Encounters) != 200 {
+				t.Fatalf("%d runs, want 200", len(result.Encounters))
+			}
+			if result.Encounters[0] < 1 || result.EncountersAt(.5) >= maxProjectedEncounters {
+				t.Errorf("encounters from %d to %d", result.Encounters[0], result.Encounters[199])
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package countable
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortMode is the order counters are shown in, SortManual keeps the order
+// of the CounterList that is changed by dragging
+type SortMode int
+
+const (
+	SortManual SortMode = iota
+	SortName
+	SortLastActivity
+	SortCount
+	SortProgress
+	SortTime
+)
+
+func (self SortMode) String() string {
+	switch self {
+	case SortManual:
+		return "Manual"
+	case SortName:
+		return "Name"
+	case SortLastActivity:
+		return "Last Activity"
+	case SortCount:
+		return "Count"
+	case SortProgress:
+		return "Progress"
+	case SortTime:
+		return "Time"
+	}
+	return fmt.Sprintf("SortMode(%d)", int(self))
+}
+
+func SortModes() []SortMode {
+	return []SortMode{SortManual, SortName, SortLastActivity, SortCount, SortProgress, SortTime}
+}
+
+// Sorted returns the counters in the order of mode, the list itself keeps
+// the manual order. Apart from names the largest values come first
+func (self *CounterList) Sorted(mode SortMode) []*Counter {
+	counters := append([]*Counter{}, self.List...)
+	SortCounters(counters, mode)
+	return counters
+}
+
+// SortCounters sorts in place, the order of equal counters is kept
+func SortCounters(counters []*Counter, mode SortMode) {
+	var less func(a, b *Counter) bool
+	switch mode {
+	case SortName:
+		less = func(a, b *Counter) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case SortLastActivity:
+		less = func(a, b *Counter) bool { return a.LastActivity().After(b.LastActivity()) }
+	case SortCount:
+		less = func(a, b *Counter) bool { return a.GetCount() > b.GetCount() }
+	case SortProgress:
+		// lower survival means more progress towards the odds
+		less = func(a, b *Counter) bool { return a.GetProgress() < b.GetProgress() }
+	case SortTime:
+		less = func(a, b *Counter) bool { return a.GetTime() > b.GetTime() }
+	default:
+		return
+	}
+	sort.SliceStable(counters, func(i, j int) bool { return less(counters[i], counters[j]) })
+}
@@ -0,0 +1,156 @@
+package countable
+
+import (
+	"log"
+	"math"
+)
+
+// the percentiles of hunters shown in the statistics
+var StatPercentiles = []float64{.5, .75, .9, .95, .99}
+
+// hunts are not projected beyond this many encounters
+const maxProjectedEncounters = 1 << 20
+
+// survival returns the chance of not having found the shiny after a number of
+// encounters in this phase, it works on copies of the progress so that any
+// method, including chains, can be projected. Every probe gets a fresh copy
+// as setting a lower count drops the chain breaks after it
+func (self *Phase) survival() func(encounters int) float64 {
+	bytes, err := self.Progress.MarshalJSON()
+	method, ok := GetMethod(self.Progress.GetType())
+	if ok && err == nil {
+		_, err = method.Unmarshal(bytes)
+	}
+	if !ok || err != nil {
+		log.Println("[WARN]\tCould not copy the progress, falling back to fixed odds. Got Error: ", err)
+		odds := self.GetOdds()
+		return func(encounters int) float64 {
+			return math.Pow(1-1/odds, float64(encounters))
+		}
+	}
+
+	bonusRolls := self.modifierRolls()
+	return func(encounters int) float64 {
+		progress, _ := method.Unmarshal(bytes)
+		if modifiable, ok := progress.(Modifiable); ok {
+			modifiable.SetBonusRolls(bonusRolls)
+		}
+		progress.SetRollsFromCount(encounters)
+		return progress.GetProgress()
+	}
+}
+
+// searchSurvival finds the lowest encounter from start on where the chance of
+// not having found the shiny drops to target
+func searchSurvival(survival func(int) float64, target float64, start int) (int, bool) {
+	if survival(start) <= target {
+		return start, true
+	}
+
+	lo, hi := start, start+1
+	for survival(hi) > target {
+		lo, hi = hi, start+(hi-start)*2
+		if hi > maxProjectedEncounters {
+			return 0, false
+		}
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if survival(mid) > target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, true
+}
+
+// expectedRemaining sums the chance of still hunting after every encounter
+// from start on, long tails are integrated with Simpson's rule
+func expectedRemaining(survival func(int) float64, start int) float64 {
+	current := survival(start)
+	if current <= 0 {
+		return 0
+	}
+
+	end, ok := searchSurvival(survival, current*1e-4, start)
+	if !ok {
+		return math.Inf(1)
+	}
+
+	const intervals = 64
+	if end-start <= intervals {
+		sum := 0.0
+		for encounter := start; encounter < end; encounter++ {
+			sum += survival(encounter)
+		}
+		return sum / current
+	}
+
+	step := float64(end-start) / intervals
+	at := func(i int) float64 {
+		return survival(start + int(math.Round(float64(i)*step)))
+	}
+	integral := at(0) + at(intervals)
+	for i := 1; i < intervals; i++ {
+		if i%2 == 1 {
+			integral += 4 * at(i)
+		} else {
+			integral += 2 * at(i)
+		}
+	}
+	integral *= step / 3
+
+	// the sum over whole encounters is half a step larger than the integral
+	return integral/current + 0.5
+}
+
+// EncountersAt returns the number of encounters in the phase after which the
+// given share of hunters would have found the shiny
+func (self *Phase) EncountersAt(percentile float64) (int, bool) {
+	return searchSurvival(self.survival(), 1-percentile, 0)
+}
+
+// ExpectedRemaining returns the mean number of encounters still needed
+func (self *Phase) ExpectedRemaining() float64 {
+	if self.IsCompleted {
+		return 0
+	}
+	return expectedRemaining(self.survival(), self.GetEncounters())
+}
+
+// LuckPercentile returns the share of hunters that would have found the shiny
+// in fewer encounters than this finished phase, lower is luckier
+func (self *Phase) LuckPercentile() (float64, bool) {
+	if !self.IsCompleted {
+		return 0, false
+	}
+
+	encounters := self.GetEncounters()
+	if self.Result != nil && self.Result.Encounter > 0 {
+		encounters = self.Result.Encounter
+	}
+	// the shiny itself was the last encounter
+	return 1 - self.survival()(max(encounters-1, 0)), true
+}
+
+// EncountersAt returns the total encounters of the counter after which the
+// given share of hunters would have found the shiny of the current phase
+func (self *Counter) EncountersAt(percentile float64) (int, bool) {
+	current := self.Phases[len(self.Phases)-1]
+	encounters, ok := current.EncountersAt(percentile)
+	return self.GetEncounters() - current.GetEncounters() + encounters, ok
+}
+
+func (self *Counter) ExpectedRemaining() float64 {
+	return self.Phases[len(self.Phases)-1].ExpectedRemaining()
+}
+
+// LuckPercentile returns the chance of finding as many shinies as this
+// finished counter in its encounters, lower is luckier
+func (self *Counter) LuckPercentile() (float64, bool) {
+	if !self.IsCompleted() {
+		return 0, false
+	}
+	return 1 - self.GetProgress(), true
+}
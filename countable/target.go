@@ -0,0 +1,80 @@
+package countable
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Gender int
+
+const (
+	GenderAny Gender = iota
+	Male
+	Female
+	Genderless
+)
+
+func (self Gender) String() string {
+	switch self {
+	case GenderAny:
+		return "Any"
+	case Male:
+		return "Male"
+	case Female:
+		return "Female"
+	case Genderless:
+		return "Genderless"
+	}
+	return fmt.Sprintf("Gender(%d)", int(self))
+}
+
+func (self Gender) Symbol() string {
+	switch self {
+	case Male:
+		return "♂"
+	case Female:
+		return "♀"
+	}
+	return ""
+}
+
+func Genders() []Gender {
+	return []Gender{GenderAny, Male, Female, Genderless}
+}
+
+// Target is the pokemon a counter is hunting, the game and method are
+// stored on the counter itself
+type Target struct {
+	Species  string
+	Form     string
+	Gender   Gender
+	Location string
+}
+
+func (self Target) IsEmpty() bool {
+	return self == Target{}
+}
+
+// String formats the target as "Species (Form) ♀ - Location" leaving out
+// anything that is not set
+func (self Target) String() string {
+	parts := []string{}
+	if self.Species != "" {
+		parts = append(parts, self.Species)
+	}
+	if self.Form != "" {
+		parts = append(parts, "("+self.Form+")")
+	}
+	if symbol := self.Gender.Symbol(); symbol != "" {
+		parts = append(parts, symbol)
+	}
+
+	description := strings.Join(parts, " ")
+	if self.Location != "" {
+		if description != "" {
+			description += " - "
+		}
+		description += self.Location
+	}
+	return description
+}
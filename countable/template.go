@@ -0,0 +1,135 @@
+package countable
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	EventBus "tallyGo/eventBus"
+)
+
+// KeyBindings are keys that only change the counter they belong to, a key
+// of 0 is not bound
+type KeyBindings struct {
+	Increase int
+	Decrease int
+}
+
+// Template presets the configuration of new counters, Options are set like
+// the options of a phase so they hold the method options, the game modifiers
+// and the encounters per press
+type Template struct {
+	Name         string
+	ProgressType ProgressType
+	Game         Game
+	HasCharm     bool
+	Options      []Option
+	Keys         KeyBindings
+}
+
+func NewTemplate(name string) *Template {
+	return &Template{name, OldOdds, GameUnset, false, []Option{}, KeyBindings{}}
+}
+
+// NewTemplateFromCounter copies the configuration of the counter's current phase
+func NewTemplateFromCounter(counter *Counter) *Template {
+	return &Template{
+		counter.Name,
+		counter.ProgressType,
+		counter.Game,
+		counter.Phases[len(counter.Phases)-1].HasCharm(),
+		counter.Options(),
+		counter.Keys,
+	}
+}
+
+// phase is set up like counters made from the template
+func (self *Template) phase() *Phase {
+	return NewPlanPhase(Plan{self.Name, self.ProgressType, self.Game, self.HasCharm, self.Options, 0})
+}
+
+// AllOptions returns every option of the method and game of the template,
+// the ones the template does not set have their default value
+func (self *Template) AllOptions() []Option {
+	return self.phase().Options()
+}
+
+// SetOptions keeps only the options the method and game of the template have
+func (self *Template) SetOptions(options []Option) {
+	self.Options = options
+	self.Options = self.AllOptions()
+}
+
+// ValidateOption returns why the value can't be set, nil when it can
+func (self *Template) ValidateOption(name string, value interface{}) error {
+	return self.phase().ValidateOption(name, value)
+}
+
+func (self *Template) Description() string {
+	description := self.ProgressType.String() + " | " + self.Game.String()
+	if self.HasCharm {
+		description += " | Charm"
+	}
+	return description
+}
+
+// UnmarshalJSON decodes the options into the types of the method's options
+func (self *Template) UnmarshalJSON(bytes []byte) (err error) {
+	type template Template
+	saved := struct {
+		*template
+		Options []struct {
+			Name  string
+			Value json.RawMessage
+		}
+	}{template: (*template)(self)}
+	if err = json.Unmarshal(bytes, &saved); err != nil {
+		return
+	}
+
+	self.Options = []Option{}
+	for _, option := range NewPlanPhase(Plan{Type: self.ProgressType, Game: self.Game}).Options() {
+		for _, s := range saved.Options {
+			if s.Name != option.Name {
+				continue
+			}
+			value := reflect.New(reflect.TypeOf(option.Value))
+			if err = json.Unmarshal(s.Value, value.Interface()); err != nil {
+				return
+			}
+			self.Options = append(self.Options, Option{option.Name, value.Elem().Interface()})
+		}
+	}
+	return
+}
+
+// NewCounterFromTemplate adds a counter configured like the template
+func (self *CounterList) NewCounterFromTemplate(template *Template) {
+	counter := NewCounter("Name...", 0, template.ProgressType)
+	self.assignID(counter)
+	counter.SetGame(template.Game)
+	counter.SetCharm(template.HasCharm)
+	for _, option := range template.Options {
+		counter.SetOption(option.Name, option.Value)
+	}
+	counter.Keys = template.Keys
+	self.List = append(self.List, counter)
+	EventBus.GetGlobalBus().SendSignal(CounterAdded, counter)
+}
+
+// ExportTemplates writes the templates to a json file to share them
+func ExportTemplates(path string, templates []*Template) (err error) {
+	var data []byte
+	if data, err = json.MarshalIndent(templates, "", "  "); err != nil {
+		return
+	}
+	return os.WriteFile(path, data, 0666)
+}
+
+func ImportTemplates(path string) (templates []*Template, err error) {
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &templates)
+	return
+}
@@ -0,0 +1,396 @@
+package countable
+
+import (
+	EventBus "tallyGo/eventBus"
+	"time"
+)
+
+// Command is a change to the counters that can be reverted
+type Command interface {
+	Do()
+	Undo()
+}
+
+// UndoStack keeps every executed Command until the app exits
+type UndoStack struct {
+	done   []Command
+	undone []Command
+}
+
+var undoStack = &UndoStack{}
+
+func GetUndoStack() *UndoStack {
+	return undoStack
+}
+
+// Do executes the command, this clears the commands that can be redone
+func (self *UndoStack) Do(command Command) {
+	command.Do()
+	self.done = append(self.done, command)
+	self.undone = nil
+	EventBus.GetGlobalBus().SendSignal(UndoStackChanged, self)
+}
+
+func (self *UndoStack) CanUndo() bool {
+	return len(self.done) > 0
+}
+
+func (self *UndoStack) CanRedo() bool {
+	return len(self.undone) > 0
+}
+
+func (self *UndoStack) Undo() {
+	if !self.CanUndo() {
+		return
+	}
+	command := self.done[len(self.done)-1]
+	self.done = self.done[:len(self.done)-1]
+	command.Undo()
+	self.undone = append(self.undone, command)
+	EventBus.GetGlobalBus().SendSignal(UndoStackChanged, self)
+}
+
+func (self *UndoStack) Redo() {
+	if !self.CanRedo() {
+		return
+	}
+	command := self.undone[len(self.undone)-1]
+	self.undone = self.undone[:len(self.undone)-1]
+	command.Do()
+	self.done = append(self.done, command)
+	EventBus.GetGlobalBus().SendSignal(UndoStackChanged, self)
+}
+
+// CommandGroup executes several commands as a single step, used when
+// more than one countable is active
+type CommandGroup []Command
+
+func (self CommandGroup) Do() {
+	for _, command := range self {
+		command.Do()
+	}
+}
+
+func (self CommandGroup) Undo() {
+	for idx := len(self) - 1; idx >= 0; idx-- {
+		self[idx].Undo()
+	}
+}
+
+type increaseCommand struct {
+	countable Countable
+	add       int
+	// the phase that was increased, counters increase their newest phase
+	phase   *Phase
+	applied int
+}
+
+func NewIncreaseCommand(countable Countable, add int) Command {
+	return &increaseCommand{countable, add, nil, 0}
+}
+
+func (self *increaseCommand) Do() {
+	if self.phase == nil {
+		switch countable := self.countable.(type) {
+		case *Phase:
+			self.phase = countable
+		case *Counter:
+			self.phase = countable.Phases[len(countable.Phases)-1]
+		default:
+			self.countable.IncreaseBy(self.add)
+			return
+		}
+	}
+	// completed phases are not increased
+	before := self.phase.Count
+	self.phase.IncreaseBy(self.add)
+	self.applied = self.phase.Count - before
+}
+
+func (self *increaseCommand) Undo() {
+	if self.phase != nil && self.applied != 0 {
+		self.phase.SetCount(self.phase.Count - self.applied)
+	}
+}
+
+type breakChainCommand struct {
+	countable Countable
+	// the phase whose chain broke, counters break the chain of their newest phase
+	phase    *Phase
+	previous []int
+}
+
+func NewBreakChainCommand(countable Countable) Command {
+	return &breakChainCommand{countable, nil, nil}
+}
+
+func (self *breakChainCommand) Do() {
+	if self.phase == nil {
+		switch countable := self.countable.(type) {
+		case *Phase:
+			self.phase = countable
+		case *Counter:
+			self.phase = countable.Phases[len(countable.Phases)-1]
+		default:
+			self.countable.BreakChain()
+			return
+		}
+	}
+	if chainer, ok := self.phase.Progress.(Chainer); ok {
+		self.previous = chainer.ChainBreaks()
+		self.phase.BreakChain()
+	}
+}
+
+func (self *breakChainCommand) Undo() {
+	if self.phase == nil || self.previous == nil {
+		return
+	}
+	if chainer, ok := self.phase.Progress.(Chainer); ok {
+		chainer.SetChainBreaks(self.previous)
+		self.phase.UpdateProgress()
+		EventBus.GetGlobalBus().SendSignal(CountChanged, self.phase.Count)
+	}
+}
+
+type setCountCommand struct {
+	countable Countable
+	previous  int
+	count     int
+}
+
+func NewSetCountCommand(countable Countable, count int) Command {
+	return &setCountCommand{countable, countable.GetCount(), count}
+}
+
+func (self *setCountCommand) Do() {
+	self.countable.SetCount(self.count)
+}
+
+func (self *setCountCommand) Undo() {
+	self.countable.SetCount(self.previous)
+}
+
+type setTimeCommand struct {
+	countable Countable
+	previous  time.Duration
+	time      time.Duration
+}
+
+func NewSetTimeCommand(countable Countable, time time.Duration) Command {
+	return &setTimeCommand{countable, countable.GetTime(), time}
+}
+
+func (self *setTimeCommand) Do() {
+	self.countable.SetTime(self.time)
+}
+
+func (self *setTimeCommand) Undo() {
+	self.countable.SetTime(self.previous)
+}
+
+type newPhaseCommand struct {
+	counter *Counter
+	phase   *Phase
+	idx     int
+}
+
+func NewNewPhaseCommand(counter *Counter) Command {
+	return &newPhaseCommand{counter, nil, 0}
+}
+
+// redoing puts back the same phase instead of creating another one
+func (self *newPhaseCommand) Do() {
+	if self.phase == nil {
+		self.phase = self.counter.NewPhase()
+		self.idx = len(self.counter.Phases) - 1
+	} else {
+		self.counter.InsertPhase(self.idx, self.phase)
+	}
+}
+
+func (self *newPhaseCommand) Undo() {
+	self.counter.RemovePhase(self.phase)
+}
+
+type removePhaseCommand struct {
+	counter *Counter
+	phase   *Phase
+	idx     int
+	removed bool
+}
+
+func NewRemovePhaseCommand(counter *Counter, phase *Phase) Command {
+	return &removePhaseCommand{counter, phase, 0, false}
+}
+
+func (self *removePhaseCommand) Do() {
+	self.idx = self.counter.phaseIdx(self.phase)
+	self.removed = self.counter.RemovePhase(self.phase)
+}
+
+func (self *removePhaseCommand) Undo() {
+	if self.removed {
+		self.counter.InsertPhase(self.idx, self.phase)
+	}
+}
+
+type removeCounterCommand struct {
+	list    *CounterList
+	counter *Counter
+	idx     int
+	removed bool
+}
+
+func NewRemoveCounterCommand(list *CounterList, counter *Counter) Command {
+	return &removeCounterCommand{list, counter, 0, false}
+}
+
+func (self *removeCounterCommand) Do() {
+	self.idx, self.removed = self.list.GetIdx(self.counter)
+	self.list.RemoveCounter(self.counter)
+}
+
+func (self *removeCounterCommand) Undo() {
+	if self.removed {
+		self.list.InsertCounter(self.idx, self.counter)
+	}
+}
+
+type setCompletedCommand struct {
+	countable   Countable
+	isCompleted bool
+	previous    map[*Phase]bool
+}
+
+func NewSetCompletedCommand(countable Countable, isCompleted bool) Command {
+	return &setCompletedCommand{countable, isCompleted, map[*Phase]bool{}}
+}
+
+func (self *setCompletedCommand) phases() []*Phase {
+	switch countable := self.countable.(type) {
+	case *Counter:
+		return countable.Phases
+	case *Phase:
+		return []*Phase{countable}
+	}
+	return nil
+}
+
+func (self *setCompletedCommand) Do() {
+	for _, phase := range self.phases() {
+		self.previous[phase] = phase.IsCompleted
+	}
+	self.countable.SetCompleted(self.isCompleted)
+}
+
+func (self *setCompletedCommand) Undo() {
+	for phase, isCompleted := range self.previous {
+		if phase.IsCompleted != isCompleted {
+			phase.SetCompleted(isCompleted)
+		}
+	}
+}
+
+type archiveCommand struct {
+	list     *CounterList
+	counter  *Counter
+	idx      int
+	archived bool
+}
+
+// NewArchiveCommand archives the counter, undoing puts it back at its place
+func NewArchiveCommand(list *CounterList, counter *Counter) Command {
+	return &archiveCommand{list, counter, 0, false}
+}
+
+func (self *archiveCommand) Do() {
+	self.idx, self.archived = self.list.GetIdx(self.counter)
+	self.list.ArchiveCounter(self.counter)
+}
+
+func (self *archiveCommand) Undo() {
+	if self.archived {
+		self.list.RestoreCounter(self.idx, self.counter)
+	}
+}
+
+type restoreCommand struct {
+	list     *CounterList
+	counter  *Counter
+	restored bool
+}
+
+// NewRestoreCommand moves an archived counter to the end of the list
+func NewRestoreCommand(list *CounterList, counter *Counter) Command {
+	return &restoreCommand{list, counter, false}
+}
+
+func (self *restoreCommand) Do() {
+	_, self.restored = self.list.getArchiveIdx(self.counter)
+	self.list.RestoreCounter(len(self.list.List), self.counter)
+}
+
+func (self *restoreCommand) Undo() {
+	if self.restored {
+		self.list.ArchiveCounter(self.counter)
+	}
+}
+
+type moveCounterCommand struct {
+	list    *CounterList
+	counter *Counter
+	from    int
+	to      int
+}
+
+func NewMoveCounterCommand(list *CounterList, counter *Counter, idx int) Command {
+	from, _ := list.GetIdx(counter)
+	return &moveCounterCommand{list, counter, from, idx}
+}
+
+func (self *moveCounterCommand) Do() {
+	self.list.MoveCounter(self.counter, self.to)
+}
+
+func (self *moveCounterCommand) Undo() {
+	self.list.MoveCounter(self.counter, self.from)
+}
+
+type movePhaseCommand struct {
+	counter *Counter
+	phase   *Phase
+	from    int
+	to      int
+}
+
+func NewMovePhaseCommand(counter *Counter, phase *Phase, idx int) Command {
+	return &movePhaseCommand{counter, phase, counter.phaseIdx(phase), idx}
+}
+
+func (self *movePhaseCommand) Do() {
+	self.counter.MovePhase(self.phase, self.to)
+}
+
+func (self *movePhaseCommand) Undo() {
+	self.counter.MovePhase(self.phase, self.from)
+}
+
+type addCounterCommand struct {
+	list    *CounterList
+	counter *Counter
+	idx     int
+}
+
+// NewAddCounterCommand inserts a new counter at idx
+func NewAddCounterCommand(list *CounterList, counter *Counter, idx int) Command {
+	return &addCounterCommand{list, counter, idx}
+}
+
+func (self *addCounterCommand) Do() {
+	self.list.InsertCounter(self.idx, self.counter)
+}
+
+func (self *addCounterCommand) Undo() {
+	self.list.RemoveCounter(self.counter)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	. "tallyGo/countable"
@@ -24,6 +25,11 @@ const (
 	StepTime                     = "StepTime"
 	LastStepTime                 = "LastStepTime"
 	OverallLuck                  = "OverallLuck"
+	ChainLength                  = "ChainLength"
+	Percentiles                  = "Percentiles"
+	ExpectedRemaining            = "ExpectedRemaining"
+	ETAProjection                = "ETAProjection"
+	Targets                      = "Targets"
 )
 
 type infoBoxWidget interface {
@@ -67,7 +73,12 @@ func NewInfoBox(counterList *CounterList) (self *infoBox) {
 	self.Append(self.widgetRevealer)
 	self.SetWidgets([]widgetType{
 		MainCount + MainTime,
+		ChainLength,
 		ProgressBar,
+		Targets,
+		ExpectedRemaining,
+		Percentiles,
+		ETAProjection,
 		StepTime + LastStepTime,
 		OverallLuck,
 	}, None, true, true)
@@ -93,7 +104,12 @@ func (self *infoBox) handleResize() {
 		self.SetWidgets([]widgetType{
 			MainCount,
 			MainTime,
+			ChainLength,
 			ProgressBar,
+			Targets,
+			ExpectedRemaining,
+			Percentiles,
+			ETAProjection,
 			StepTime,
 			LastStepTime,
 			OverallLuck,
@@ -102,7 +118,12 @@ func (self *infoBox) handleResize() {
 		self.isExpanded = true
 		self.SetWidgets([]widgetType{
 			MainCount + MainTime,
+			ChainLength,
 			ProgressBar,
+			Targets,
+			ExpectedRemaining,
+			Percentiles,
+			ETAProjection,
 			StepTime + LastStepTime,
 			OverallLuck,
 		}, None, true, true)
@@ -162,6 +183,10 @@ func (self *infoBox) AddWidget(type_ widgetType, showBorder bool) {
 		mainTimeLabel := newMainTimeLabel()
 		self.Box.Append(mainTimeLabel)
 		self.widgets[MainTime] = mainTimeLabel
+	case ChainLength:
+		chainLabel := newChainLabel()
+		self.Box.Append(chainLabel)
+		self.widgets[ChainLength] = chainLabel
 	case ProgressBar:
 		mainProgressBar := newMainProgressBar()
 		self.Box.Append(mainProgressBar)
@@ -180,6 +205,22 @@ func (self *infoBox) AddWidget(type_ widgetType, showBorder bool) {
 		lastStepTime := newLastStepTime()
 		self.Box.Append(lastStepTime)
 		self.widgets[LastStepTime] = lastStepTime
+	case Percentiles:
+		percentiles := newPercentiles()
+		self.Box.Append(percentiles)
+		self.widgets[Percentiles] = percentiles
+	case ExpectedRemaining:
+		remaining := newRemainingLabel()
+		self.Box.Append(remaining)
+		self.widgets[ExpectedRemaining] = remaining
+	case ETAProjection:
+		projection := newETAProjection()
+		self.Box.Append(projection)
+		self.widgets[ETAProjection] = projection
+	case Targets:
+		breakdown := newTargetBreakdown()
+		self.Box.Append(breakdown)
+		self.widgets[Targets] = breakdown
 	case OverallLuck:
 		overallLuck := newOverallLuck(self.counterList)
 		self.Box.Append(overallLuck)
@@ -286,6 +327,12 @@ func (self *widgetRevealer) setWidget(widget widgetType) {
 		self.selectedWidget = mainTimeLabel
 		label.SetText("Time")
 		box.Append(mainTimeLabel)
+	case ChainLength:
+		chainLabel := newChainLabel()
+		chainLabel.setCounter(self.countable)
+		self.selectedWidget = chainLabel
+		label.SetText("Chain")
+		box.Append(chainLabel)
 	case ProgressBar:
 		mainProgressBar := newMainProgressBar()
 		mainProgressBar.setCounter(self.countable)
@@ -304,6 +351,30 @@ func (self *widgetRevealer) setWidget(widget widgetType) {
 		self.selectedWidget = lastStep
 		label.SetText("Last Step")
 		box.Append(lastStep)
+	case Percentiles:
+		percentiles := newPercentiles()
+		percentiles.setCounter(self.countable)
+		self.selectedWidget = percentiles
+		label.SetText("Encounters Needed")
+		box.Append(percentiles)
+	case ExpectedRemaining:
+		remaining := newRemainingLabel()
+		remaining.setCounter(self.countable)
+		self.selectedWidget = remaining
+		label.SetText("Expected Remaining")
+		box.Append(remaining)
+	case ETAProjection:
+		projection := newETAProjection()
+		projection.setCounter(self.countable)
+		self.selectedWidget = projection
+		label.SetText("ETA")
+		box.Append(projection)
+	case Targets:
+		breakdown := newTargetBreakdown()
+		breakdown.setCounter(self.countable)
+		self.selectedWidget = breakdown
+		label.SetText("Targets")
+		box.Append(breakdown)
 	}
 	self.SetRevealChild(true)
 }
@@ -353,6 +424,12 @@ func (self *WidgetBox) Append(widget widgetType, countable Countable) {
 		self.Box.Append(mainTimeLabel)
 		self.widgets = append(self.widgets, mainTimeLabel)
 		break
+	case ChainLength:
+		chainLabel := newChainLabel()
+		chainLabel.SetHExpand(true)
+		self.Box.Append(chainLabel)
+		self.widgets = append(self.widgets, chainLabel)
+		break
 	case ProgressBar:
 		mainProgressBar := newMainProgressBar()
 		mainProgressBar.SetHExpand(true)
@@ -371,6 +448,12 @@ func (self *WidgetBox) Append(widget widgetType, countable Countable) {
 		self.Box.Append(lastStep)
 		self.widgets = append(self.widgets, lastStep)
 		break
+	case ExpectedRemaining:
+		remaining := newRemainingLabel()
+		remaining.SetHExpand(true)
+		self.Box.Append(remaining)
+		self.widgets = append(self.widgets, remaining)
+		break
 	}
 	self.FirstChild().(*gtk.Box).SetHExpand(true)
 }
@@ -415,9 +498,10 @@ func (self *WidgetBox) removeCSSClass(name string) {
 
 type countLabel struct {
 	*gtk.Box
-	countable  Countable
-	labelTitle *gtk.Label
-	labelCount *gtk.Label
+	countable       Countable
+	labelTitle      *gtk.Label
+	labelCount      *gtk.Label
+	labelEncounters *gtk.Label
 }
 
 func newMainCountLabel() (self *countLabel) {
@@ -426,15 +510,19 @@ func newMainCountLabel() (self *countLabel) {
 		Countable(nil),
 		gtk.NewLabel("Count"),
 		gtk.NewLabel("---"),
+		gtk.NewLabel(""),
 	}
 
 	self.Box.AddCSSClass("infoBoxRow")
 	self.Box.Append(self.labelTitle)
 	self.Box.Append(self.labelCount)
+	self.Box.Append(self.labelEncounters)
 
 	self.labelTitle.SetVisible(false)
 	self.labelTitle.SetName("title")
 	self.labelCount.SetHExpand(true)
+	self.labelEncounters.SetVisible(false)
+	self.labelEncounters.AddCSSClass("encountersLabel")
 
 	EventBus.GetGlobalBus().Subscribe(CountChanged, self.UpdateCount)
 
@@ -460,6 +548,11 @@ func (self *countLabel) UpdateCount(...interface{}) {
 		return
 	}
 	self.labelCount.SetText(self.String())
+
+	// only show the encounters when a press counts more than one pokemon
+	encounters := self.countable.GetEncounters()
+	self.labelEncounters.SetVisible(encounters != self.countable.GetCount())
+	self.labelEncounters.SetText(fmt.Sprintf("%d encounters", encounters))
 }
 
 func (self *countLabel) String() string {
@@ -516,6 +609,98 @@ func (self *countLabel) removeCSSClass(name string) {
 	self.RemoveCSSClass(name)
 }
 
+type chainLabel struct {
+	*gtk.Box
+	countable  Countable
+	labelTitle *gtk.Label
+	labelChain *gtk.Label
+}
+
+func newChainLabel() (self *chainLabel) {
+	self = &chainLabel{
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		Countable(nil),
+		gtk.NewLabel("Chain"),
+		gtk.NewLabel("---"),
+	}
+
+	self.Box.AddCSSClass("infoBoxRow")
+	self.Box.Append(self.labelTitle)
+	self.Box.Append(self.labelChain)
+
+	self.labelTitle.SetVisible(false)
+	self.labelTitle.SetName("title")
+	self.labelChain.SetHExpand(true)
+
+	EventBus.GetGlobalBus().Subscribe(CountChanged, self.UpdateChain)
+
+	return
+}
+
+func (self *chainLabel) setCounter(countable Countable) {
+	if countable == Countable(nil) {
+		return
+	}
+	self.countable = countable
+	self.UpdateChain()
+}
+
+// UpdateChain hides the label for methods without a chain
+func (self *chainLabel) UpdateChain(...interface{}) {
+	if self.countable == Countable(nil) {
+		return
+	}
+	self.SetVisible(self.countable.GetProgressType().IsChain())
+	self.labelChain.SetText(fmt.Sprintf("%d", self.countable.GetChain()))
+}
+
+func (self *chainLabel) setBorder(setShown bool) {
+	if setShown {
+		self.AddCSSClass("infoBoxShowBackground")
+	} else {
+		self.RemoveCSSClass("infoBoxShowBackground")
+	}
+}
+
+func (self *chainLabel) setTitle(set bool) {
+	self.labelTitle.SetVisible(set)
+}
+
+func (self *chainLabel) setExpand(set bool) {
+	self.labelChain.SetVExpand(set)
+	if set {
+		self.labelChain.AddCSSClass("expandWidget")
+	} else {
+		self.labelChain.RemoveCSSClass("expandWidget")
+	}
+}
+
+func (self *chainLabel) connectRevealer(revealer *widgetRevealer) {
+	clickController := gtk.NewGestureClick()
+	clickController.ConnectPressed(func(_ int, _ float64, _ float64) {
+		if revealer.widgetType == ChainLength {
+			revealer.setWidget(None)
+		} else {
+			self.AddCSSClass("selected")
+			revealer.setWidget(ChainLength)
+			revealer.ConnectChanged("ChangeWidget", func() {
+				if revealer.widgetType == ChainLength {
+					self.removeCSSClass("selected")
+				}
+			})
+		}
+	})
+	self.AddController(clickController)
+}
+
+func (self *chainLabel) addCSSClass(name string) {
+	self.AddCSSClass(name)
+}
+
+func (self *chainLabel) removeCSSClass(name string) {
+	self.RemoveCSSClass(name)
+}
+
 type timeLabel struct {
 	*gtk.Box
 
@@ -704,11 +889,8 @@ func (self *mainProgressBar) Update(...interface{}) {
 	self.progressBar.RemoveCSSClass("progressRed")
 
 	var odds int
-	switch self.countable.GetProgressType() {
-	case OldOdds:
-		odds = 8192
-	case NewOdds:
-		odds = 4096
+	if !self.countable.GetProgressType().IsChain() {
+		odds = int(self.countable.GetOdds())
 	}
 
 	switch {
@@ -877,7 +1059,6 @@ type lastStepTime struct {
 	*gtk.Box
 
 	countable Countable
-	lastTime  time.Duration
 
 	title *gtk.Label
 	label *gtk.Label
@@ -887,7 +1068,6 @@ func newLastStepTime() (self *lastStepTime) {
 	self = &lastStepTime{
 		gtk.NewBox(gtk.OrientationHorizontal, 0),
 		Countable(nil),
-		-1,
 		gtk.NewLabel("Last Step"),
 		gtk.NewLabel("---"),
 	}
@@ -908,18 +1088,20 @@ func newLastStepTime() (self *lastStepTime) {
 func (self *lastStepTime) setCounter(countable Countable) {
 	self.countable = countable
 	if self.countable == Countable(nil) {
-		self.lastTime = 0
 		return
 	}
+	self.Update()
 }
 
 func (self *lastStepTime) Update(...interface{}) {
-	if self.lastTime == -1 {
-		self.lastTime = self.countable.GetTime()
+	if self.countable == Countable(nil) {
 		return
 	}
-	self.label.SetText(shortFormatTime(self.countable.GetTime() - self.lastTime))
-	self.lastTime = self.countable.GetTime()
+	if stepTime, ok := self.countable.LastStepTime(); ok {
+		self.label.SetText(shortFormatTime(stepTime))
+	} else {
+		self.label.SetText("---")
+	}
 }
 
 func (self *lastStepTime) setBorder(setShown bool) {
@@ -969,6 +1151,450 @@ func (self *lastStepTime) removeCSSClass(name string) {
 	self.RemoveCSSClass(name)
 }
 
+// percentiles shows the encounters after which a share of hunters would
+// have found the shiny
+type percentiles struct {
+	*gtk.Box
+	countable Countable
+	title     *gtk.Label
+	labels    []*gtk.Label
+}
+
+func newPercentiles() (self *percentiles) {
+	self = &percentiles{
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		Countable(nil),
+		gtk.NewLabel("Encounters Needed"),
+		[]*gtk.Label{},
+	}
+
+	self.Box.AddCSSClass("infoBoxRow")
+	self.Box.Append(self.title)
+	self.title.SetName("title")
+	self.title.SetVisible(false)
+
+	for range StatPercentiles {
+		label := gtk.NewLabel("---")
+		label.SetHExpand(true)
+		label.SetJustify(gtk.JustifyCenter)
+		label.AddCSSClass("percentileLabel")
+		self.Box.Append(label)
+		self.labels = append(self.labels, label)
+	}
+
+	EventBus.GetGlobalBus().Subscribe(CountChanged, self.Update)
+
+	return
+}
+
+func (self *percentiles) setCounter(countable Countable) {
+	if countable == Countable(nil) {
+		return
+	}
+	self.countable = countable
+	self.Update()
+}
+
+func (self *percentiles) Update(...interface{}) {
+	if self.countable == Countable(nil) {
+		return
+	}
+	for idx, percentile := range StatPercentiles {
+		encounters, ok := self.countable.EncountersAt(percentile)
+		value := "---"
+		if ok {
+			value = fmt.Sprintf("%d", encounters)
+		}
+		self.labels[idx].SetText(fmt.Sprintf("%.0f%%\n%s", percentile*100, value))
+	}
+}
+
+func (self *percentiles) setBorder(setShown bool) {
+	if setShown {
+		self.Box.AddCSSClass("infoBoxShowBackground")
+	} else {
+		self.Box.RemoveCSSClass("infoBoxShowBackground")
+	}
+}
+
+func (self *percentiles) setTitle(set bool) {
+	self.title.SetVisible(set)
+}
+
+func (self *percentiles) setExpand(set bool) {
+	for _, label := range self.labels {
+		label.SetVExpand(set)
+	}
+}
+
+func (self *percentiles) connectRevealer(revealer *widgetRevealer) {
+	clickController := gtk.NewGestureClick()
+	clickController.ConnectPressed(func(_ int, _ float64, _ float64) {
+		if revealer.widgetType == Percentiles {
+			revealer.setWidget(None)
+		} else {
+			self.AddCSSClass("selected")
+			revealer.setWidget(Percentiles)
+			revealer.ConnectChanged("ChangeWidget", func() {
+				if revealer.widgetType == Percentiles {
+					self.removeCSSClass("selected")
+				}
+			})
+		}
+	})
+	self.AddController(clickController)
+}
+
+func (self *percentiles) addCSSClass(name string) {
+	self.AddCSSClass(name)
+}
+
+func (self *percentiles) removeCSSClass(name string) {
+	self.RemoveCSSClass(name)
+}
+
+// remainingLabel shows the expected encounters until the shiny, once the
+// hunt is finished it shows how lucky the find was instead
+type remainingLabel struct {
+	*gtk.Box
+	countable Countable
+	title     *gtk.Label
+	label     *gtk.Label
+}
+
+func newRemainingLabel() (self *remainingLabel) {
+	self = &remainingLabel{
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		Countable(nil),
+		gtk.NewLabel("Expected Remaining"),
+		gtk.NewLabel("---"),
+	}
+
+	self.Box.AddCSSClass("infoBoxRow")
+	self.Box.Append(self.title)
+	self.Box.Append(self.label)
+	self.title.SetName("title")
+	self.title.SetVisible(false)
+	self.label.SetHExpand(true)
+
+	EventBus.GetGlobalBus().Subscribe(CountChanged, self.Update)
+	EventBus.GetGlobalBus().Subscribe(CompletedStatus, self.Update)
+
+	return
+}
+
+func (self *remainingLabel) setCounter(countable Countable) {
+	if countable == Countable(nil) {
+		return
+	}
+	self.countable = countable
+	self.Update()
+}
+
+func (self *remainingLabel) Update(...interface{}) {
+	if self.countable == Countable(nil) {
+		return
+	}
+	if luck, ok := self.countable.LuckPercentile(); ok {
+		self.title.SetText("Luck Percentile")
+		self.label.SetText(fmt.Sprintf("%.01f%%", luck*100))
+		return
+	}
+
+	self.title.SetText("Expected Remaining")
+	remaining := self.countable.ExpectedRemaining()
+	if math.IsInf(remaining, 1) {
+		self.label.SetText("---")
+	} else {
+		self.label.SetText(fmt.Sprintf("%.0f encounters", remaining))
+	}
+}
+
+func (self *remainingLabel) setBorder(setShown bool) {
+	if setShown {
+		self.Box.AddCSSClass("infoBoxShowBackground")
+	} else {
+		self.Box.RemoveCSSClass("infoBoxShowBackground")
+	}
+}
+
+func (self *remainingLabel) setTitle(set bool) {
+	self.title.SetVisible(set)
+}
+
+func (self *remainingLabel) setExpand(set bool) {
+	self.label.SetVExpand(set)
+	if set {
+		self.label.AddCSSClass("expandWidget")
+	} else {
+		self.label.RemoveCSSClass("expandWidget")
+	}
+}
+
+func (self *remainingLabel) connectRevealer(revealer *widgetRevealer) {
+	clickController := gtk.NewGestureClick()
+	clickController.ConnectPressed(func(_ int, _ float64, _ float64) {
+		if revealer.widgetType == ExpectedRemaining {
+			revealer.setWidget(None)
+		} else {
+			self.AddCSSClass("selected")
+			revealer.setWidget(ExpectedRemaining)
+			revealer.ConnectChanged("ChangeWidget", func() {
+				if revealer.widgetType == ExpectedRemaining {
+					self.removeCSSClass("selected")
+				}
+			})
+		}
+	})
+	self.AddController(clickController)
+}
+
+func (self *remainingLabel) addCSSClass(name string) {
+	self.AddCSSClass(name)
+}
+
+func (self *remainingLabel) removeCSSClass(name string) {
+	self.RemoveCSSClass(name)
+}
+
+// etaProjection shows the hunt time until the milestones of the hunt at the
+// current encounter rate
+type etaProjection struct {
+	*gtk.Box
+	countable Countable
+	title     *gtk.Label
+	grid      *gtk.Grid
+}
+
+func newETAProjection() (self *etaProjection) {
+	self = &etaProjection{
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		Countable(nil),
+		gtk.NewLabel("ETA"),
+		gtk.NewGrid(),
+	}
+
+	self.Box.AddCSSClass("infoBoxRow")
+	self.Box.Append(self.title)
+	self.Box.Append(self.grid)
+	self.title.SetName("title")
+	self.title.SetVisible(false)
+	self.grid.SetHExpand(true)
+	self.grid.SetColumnSpacing(12)
+	self.grid.SetHAlign(gtk.AlignCenter)
+
+	EventBus.GetGlobalBus().Subscribe(CountChanged, self.Update)
+
+	return
+}
+
+func (self *etaProjection) setCounter(countable Countable) {
+	if countable == Countable(nil) {
+		return
+	}
+	self.countable = countable
+	self.Update()
+}
+
+func (self *etaProjection) Update(...interface{}) {
+	if self.countable == Countable(nil) {
+		return
+	}
+	for child := self.grid.FirstChild(); child != nil; child = self.grid.FirstChild() {
+		self.grid.Remove(child)
+	}
+
+	etas, ok := self.countable.Projection()
+	if !ok {
+		self.grid.Attach(gtk.NewLabel("---"), 0, 0, 1, 1)
+		return
+	}
+
+	for row, eta := range etas {
+		name := gtk.NewLabel(eta.Name)
+		name.SetXAlign(0)
+		estimate := gtk.NewLabel("reached")
+		band := gtk.NewLabel("")
+		if !eta.Reached {
+			estimate.SetText(formatETA(eta.Estimate))
+			band.SetText(fmt.Sprintf("%s - %s", formatETA(eta.Low), formatETA(eta.High)))
+		}
+		estimate.SetXAlign(1)
+		band.SetXAlign(1)
+		band.AddCSSClass("etaBandLabel")
+
+		self.grid.Attach(name, 0, row, 1, 1)
+		self.grid.Attach(estimate, 1, row, 1, 1)
+		self.grid.Attach(band, 2, row, 1, 1)
+	}
+}
+
+func (self *etaProjection) setBorder(setShown bool) {
+	if setShown {
+		self.Box.AddCSSClass("infoBoxShowBackground")
+	} else {
+		self.Box.RemoveCSSClass("infoBoxShowBackground")
+	}
+}
+
+func (self *etaProjection) setTitle(set bool) {
+	self.title.SetVisible(set)
+}
+
+func (self *etaProjection) setExpand(set bool) {
+	self.grid.SetVExpand(set)
+}
+
+func (self *etaProjection) connectRevealer(revealer *widgetRevealer) {
+	clickController := gtk.NewGestureClick()
+	clickController.ConnectPressed(func(_ int, _ float64, _ float64) {
+		if revealer.widgetType == ETAProjection {
+			revealer.setWidget(None)
+		} else {
+			self.AddCSSClass("selected")
+			revealer.setWidget(ETAProjection)
+			revealer.ConnectChanged("ChangeWidget", func() {
+				if revealer.widgetType == ETAProjection {
+					self.removeCSSClass("selected")
+				}
+			})
+		}
+	})
+	self.AddController(clickController)
+}
+
+func (self *etaProjection) addCSSClass(name string) {
+	self.AddCSSClass(name)
+}
+
+func (self *etaProjection) removeCSSClass(name string) {
+	self.RemoveCSSClass(name)
+}
+
+// targetBreakdown shows the share of the encounters and the chance of having
+// found each target of a multi target hunt, it is hidden for other hunts
+type targetBreakdown struct {
+	*gtk.Box
+	countable Countable
+	title     *gtk.Label
+	grid      *gtk.Grid
+}
+
+func newTargetBreakdown() (self *targetBreakdown) {
+	self = &targetBreakdown{
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		Countable(nil),
+		gtk.NewLabel("Targets"),
+		gtk.NewGrid(),
+	}
+
+	self.Box.AddCSSClass("infoBoxRow")
+	self.Box.Append(self.title)
+	self.Box.Append(self.grid)
+	self.title.SetName("title")
+	self.title.SetVisible(false)
+	self.grid.SetHExpand(true)
+	self.grid.SetColumnSpacing(12)
+	self.grid.SetHAlign(gtk.AlignCenter)
+
+	EventBus.GetGlobalBus().Subscribe(CountChanged, self.Update)
+
+	return
+}
+
+func (self *targetBreakdown) setCounter(countable Countable) {
+	if countable == Countable(nil) {
+		return
+	}
+	self.countable = countable
+	self.Update()
+}
+
+func (self *targetBreakdown) Update(...interface{}) {
+	if self.countable == Countable(nil) {
+		return
+	}
+	for child := self.grid.FirstChild(); child != nil; child = self.grid.FirstChild() {
+		self.grid.Remove(child)
+	}
+
+	var targets []TargetProgress
+	breakdown, ok := self.countable.(TargetBreakdown)
+	if ok {
+		targets, ok = breakdown.TargetProgress()
+	}
+	self.SetVisible(ok)
+	if !ok {
+		return
+	}
+
+	anyTarget := gtk.NewLabel("Any target")
+	anyTarget.SetXAlign(0)
+	anyChance := gtk.NewLabel(fmt.Sprintf("%.03f%%", (1-self.countable.GetProgress())*100))
+	anyChance.SetXAlign(1)
+	self.grid.Attach(anyTarget, 0, 0, 1, 1)
+	self.grid.Attach(anyChance, 2, 0, 1, 1)
+
+	for row, target := range targets {
+		name := target.Species
+		if name == "" {
+			name = fmt.Sprintf("Target %d", row+1)
+		}
+		species := gtk.NewLabel(fmt.Sprintf("%s (%g%%, 1/%.0f)", name, target.Slot, target.Odds))
+		species.SetXAlign(0)
+		encounters := gtk.NewLabel(fmt.Sprintf("%.0f encounters", target.Encounters))
+		encounters.SetXAlign(1)
+		chance := gtk.NewLabel(fmt.Sprintf("%.03f%%", (1-target.Progress)*100))
+		chance.SetXAlign(1)
+
+		self.grid.Attach(species, 0, row+1, 1, 1)
+		self.grid.Attach(encounters, 1, row+1, 1, 1)
+		self.grid.Attach(chance, 2, row+1, 1, 1)
+	}
+}
+
+func (self *targetBreakdown) setBorder(setShown bool) {
+	if setShown {
+		self.Box.AddCSSClass("infoBoxShowBackground")
+	} else {
+		self.Box.RemoveCSSClass("infoBoxShowBackground")
+	}
+}
+
+func (self *targetBreakdown) setTitle(set bool) {
+	self.title.SetVisible(set)
+}
+
+func (self *targetBreakdown) setExpand(set bool) {
+	self.grid.SetVExpand(set)
+}
+
+func (self *targetBreakdown) connectRevealer(revealer *widgetRevealer) {
+	clickController := gtk.NewGestureClick()
+	clickController.ConnectPressed(func(_ int, _ float64, _ float64) {
+		if revealer.widgetType == Targets {
+			revealer.setWidget(None)
+		} else {
+			self.AddCSSClass("selected")
+			revealer.setWidget(Targets)
+			revealer.ConnectChanged("ChangeWidget", func() {
+				if revealer.widgetType == Targets {
+					self.removeCSSClass("selected")
+				}
+			})
+		}
+	})
+	self.AddController(clickController)
+}
+
+func (self *targetBreakdown) addCSSClass(name string) {
+	self.AddCSSClass(name)
+}
+
+func (self *targetBreakdown) removeCSSClass(name string) {
+	self.RemoveCSSClass(name)
+}
+
 type overallLuck struct {
 	*gtk.Box
 
@@ -995,6 +1621,7 @@ func newOverallLuck(list *CounterList) (self *overallLuck) {
 	self.setProgress()
 
 	EventBus.GetGlobalBus().Subscribe(CountChanged, self.setProgress)
+	EventBus.GetGlobalBus().Subscribe(CompletedStatus, self.setProgress)
 
 	return
 }
@@ -1007,6 +1634,11 @@ func (self *overallLuck) setProgress(...interface{}) {
 	self.luck.SetShowText(true)
 	self.luck.SetHExpand(true)
 
+	self.luck.RemoveCSSClass("progressGreen")
+	self.luck.RemoveCSSClass("progressYellow")
+	self.luck.RemoveCSSClass("progressOrange")
+	self.luck.RemoveCSSClass("progressRed")
+
 	switch {
 	case luck < 0.3:
 		self.luck.AddCSSClass("progressRed")
@@ -1116,3 +1748,10 @@ func shortFormatTime(duration time.Duration) (format string) {
 	}
 	return
 }
+
+func formatETA(duration time.Duration) string {
+	if duration < time.Hour {
+		return fmt.Sprintf("%dm %02ds", int(duration.Minutes()), int(duration.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh %02dm", int(duration.Hours()), int(duration.Minutes())%60)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"reflect"
 	"strconv"
+	"strings"
 	. "tallyGo/countable"
 	"time"
 
@@ -14,15 +15,16 @@ import (
 
 type EditDialog struct {
 	*gtk.Dialog
-	list      *gtk.Box
-	buttonRow *gtk.Box
+	list       *gtk.Box
+	buttonRow  *gtk.Box
+	errorLabel *gtk.Label
 
 	rows map[string]interface{}
 
 	countable Countable
 }
 
-func NewEditDialog(countable Countable) *EditDialog {
+func newDialog(countable Countable) *EditDialog {
 	var mainWindow *gtk.ApplicationWindow
 	var ok bool
 	if mainWindow, ok = gtk.WindowListToplevels()[0].(*gtk.ApplicationWindow); !ok {
@@ -39,7 +41,22 @@ func NewEditDialog(countable Countable) *EditDialog {
 	window.SetChild(listBox)
 
 	rows := make(map[string]interface{})
-	this := EditDialog{window, listBox, gtk.NewBox(gtk.OrientationHorizontal, 0), rows, countable}
+	return &EditDialog{window, listBox, gtk.NewBox(gtk.OrientationHorizontal, 0), gtk.NewLabel(""), rows, countable}
+}
+
+// appendButtonRow has to be called after all rows are added
+func (self *EditDialog) appendButtonRow() {
+	self.errorLabel.AddCSSClass("editDialogError")
+	self.errorLabel.SetWrap(true)
+	self.errorLabel.SetVisible(false)
+	self.list.Append(self.errorLabel)
+	self.list.Append(self.buttonRow)
+	self.buttonRow.AddCSSClass("editDialogButtonRow")
+	self.buttonRow.SetHAlign(gtk.AlignEnd)
+}
+
+func NewEditDialog(countable Countable) *EditDialog {
+	this := newDialog(countable)
 
 	switch countable.(type) {
 	case *Phase:
@@ -47,24 +64,33 @@ func NewEditDialog(countable Countable) *EditDialog {
 		this.NewRow("Name", phase.Name)
 		this.NewRow("Count", phase.Count)
 		this.NewRow("Time", phase.Time)
-		this.NewRow("HuntType", fmt.Sprint(phase.Progress.GetType()))
+		this.NewRow("HuntType", phase.GetProgressType())
+		for _, option := range phase.Options() {
+			this.NewRow(option.Name, option.Value)
+		}
 		this.AddButton("cancel", func() {
 			this.Close()
 		})
 		this.AddButton("confirm", func() {
+			if !this.validOptions(phase.Options(), phase.ValidateOption) {
+				return
+			}
 			if name, ok := this.rows["Name"].(string); ok {
 				phase.SetName(name)
 			}
-			if count, ok := this.rows["Count"].(int); ok {
-				println(count)
-				phase.SetCount(count)
+			for _, option := range phase.Options() {
+				if value, ok := this.rows[option.Name]; ok {
+					phase.SetOption(option.Name, value)
+				}
 			}
-			if duration, ok := this.rows["Time"].(time.Duration); ok {
-				phase.SetTime(duration)
+			if count, ok := this.rows["Count"].(int); ok && count != phase.Count {
+				GetUndoStack().Do(NewSetCountCommand(phase, count))
 			}
-			if type_str, ok := this.rows["HuntType"].(string); ok {
-				type_, _ := strconv.Atoi(type_str)
-				phase.SetProgressType(ProgressType(type_))
+			if duration, ok := this.rows["Time"].(time.Duration); ok && duration != phase.Time {
+				GetUndoStack().Do(NewSetTimeCommand(phase, duration))
+			}
+			if type_, ok := this.rows["HuntType"].(ProgressType); ok && type_ != phase.GetProgressType() {
+				phase.SetProgressType(type_)
 			}
 			this.Close()
 		})
@@ -73,21 +99,52 @@ func NewEditDialog(countable Countable) *EditDialog {
 		counter := countable.(*Counter)
 		this.NewRow("Name", counter.Name)
 		this.NewRow("Count", counter.GetCount())
-		this.NewRow("HuntType", fmt.Sprint(counter.ProgressType))
+		this.NewRow("HuntType", counter.ProgressType)
+		this.NewRow("Game", counter.Game)
+		this.NewRow("Species", counter.Target.Species)
+		this.NewRow("Form", counter.Target.Form)
+		this.NewRow("Gender", counter.Target.Gender)
+		this.NewRow("Location", counter.Target.Location)
+		this.NewRow("Tags", strings.Join(counter.Tags, ", "))
 		this.NewRow("Shiny Charm", counter.HasCharm())
+		for _, option := range counter.Options() {
+			this.NewRow(option.Name, option.Value)
+		}
 		this.AddButton("cancel", func() {
 			this.Close()
 		})
 		this.AddButton("confirm", func() {
+			if !this.validOptions(counter.Options(), counter.ValidateOption) {
+				return
+			}
 			if name, ok := this.rows["Name"].(string); ok {
 				counter.SetName(name)
 			}
-			if count, ok := this.rows["Count"].(int); ok {
-				counter.SetCount(count)
+			for _, option := range counter.Options() {
+				if value, ok := this.rows[option.Name]; ok {
+					counter.SetOption(option.Name, value)
+				}
 			}
-			if type_str, ok := this.rows["HuntType"].(string); ok {
-				type_, _ := strconv.Atoi(type_str)
-				counter.SetProgressType(ProgressType(type_))
+			if count, ok := this.rows["Count"].(int); ok && count != counter.GetCount() {
+				GetUndoStack().Do(NewSetCountCommand(counter, count))
+			}
+			if type_, ok := this.rows["HuntType"].(ProgressType); ok && type_ != counter.ProgressType {
+				counter.SetProgressType(type_)
+			}
+			if game, ok := this.rows["Game"].(Game); ok && game != counter.Game {
+				counter.SetGame(game)
+			}
+			target := Target{
+				Species:  this.rows["Species"].(string),
+				Form:     this.rows["Form"].(string),
+				Gender:   this.rows["Gender"].(Gender),
+				Location: this.rows["Location"].(string),
+			}
+			if target != counter.Target {
+				counter.SetTarget(target)
+			}
+			if tags, ok := this.rows["Tags"].(string); ok && tags != strings.Join(counter.Tags, ", ") {
+				counter.SetTags(strings.Split(tags, ","))
 			}
 			if hasCharm, ok := this.rows["Shiny Charm"].(bool); ok {
 				counter.SetCharm(hasCharm)
@@ -95,13 +152,42 @@ func NewEditDialog(countable Countable) *EditDialog {
 			this.Close()
 		})
 		break
+	case *Group:
+		group := countable.(*Group)
+		this.NewRow("Name", group.Name)
+		this.AddButton("cancel", func() {
+			this.Close()
+		})
+		this.AddButton("confirm", func() {
+			if name, ok := this.rows["Name"].(string); ok {
+				group.SetName(name)
+			}
+			this.Close()
+		})
+		break
 	}
 
-	this.list.Append(this.buttonRow)
-	this.buttonRow.AddCSSClass("editDialogButtonRow")
-	this.buttonRow.SetHAlign(gtk.AlignEnd)
+	this.appendButtonRow()
 
-	return &this
+	return this
+}
+
+// validOptions shows why the first invalid option can't be set, the dialog
+// should stay open until it is fixed
+func (self *EditDialog) validOptions(options []Option, validate func(string, interface{}) error) bool {
+	for _, option := range options {
+		value, ok := self.rows[option.Name]
+		if !ok {
+			continue
+		}
+		if err := validate(option.Name, value); err != nil {
+			self.errorLabel.SetText(fmt.Sprintf("%s: %s", option.Name, err))
+			self.errorLabel.SetVisible(true)
+			return false
+		}
+	}
+	self.errorLabel.SetVisible(false)
+	return true
 }
 
 func (self *EditDialog) NewRow(title string, value interface{}) {
@@ -133,6 +219,58 @@ func (self *EditDialog) NewRow(title string, value interface{}) {
 		row.ConnectChanged(func() {
 			self.rows[title] = row.state.Active()
 		})
+	case ProgressType:
+		types, names := []ProgressType{}, []string{}
+		for _, method := range Methods() {
+			types = append(types, method.Type)
+			names = append(names, method.DisplayName)
+		}
+		row := NewDialogChoiceRow(title, types, names, value.(ProgressType))
+		self.list.Append(row)
+
+		row.ConnectChanged(func() {
+			self.rows[title] = row.Selected()
+		})
+	case Game:
+		names := []string{}
+		for _, game := range Games() {
+			names = append(names, game.String())
+		}
+		row := NewDialogChoiceRow(title, Games(), names, value.(Game))
+		self.list.Append(row)
+
+		row.ConnectChanged(func() {
+			self.rows[title] = row.Selected()
+		})
+	case Outcome:
+		names := []string{}
+		for _, outcome := range Outcomes() {
+			names = append(names, outcome.String())
+		}
+		row := NewDialogChoiceRow(title, Outcomes(), names, value.(Outcome))
+		self.list.Append(row)
+
+		row.ConnectChanged(func() {
+			self.rows[title] = row.Selected()
+		})
+	case HuntTargets:
+		row := NewDialogTargetsRow(title, value.(HuntTargets))
+		self.list.Append(row)
+
+		row.ConnectChanged(func() {
+			self.rows[title] = row.Targets()
+		})
+	case Gender:
+		names := []string{}
+		for _, gender := range Genders() {
+			names = append(names, gender.String())
+		}
+		row := NewDialogChoiceRow(title, Genders(), names, value.(Gender))
+		self.list.Append(row)
+
+		row.ConnectChanged(func() {
+			self.rows[title] = row.Selected()
+		})
 	}
 }
 
@@ -171,6 +309,50 @@ func (self *DialogBoolRow) ConnectChanged(callback func()) {
 	self.state.ConnectToggled(callback)
 }
 
+func (self *DialogBoolRow) Active() bool {
+	return self.state.Active()
+}
+
+type DialogChoiceRow[T comparable] struct {
+	*gtk.Box
+	dropDown *gtk.DropDown
+	choices  []T
+}
+
+func NewDialogChoiceRow[T comparable](title string, choices []T, names []string, value T) (self *DialogChoiceRow[T]) {
+	self = &DialogChoiceRow[T]{
+		Box:     gtk.NewBox(gtk.OrientationHorizontal, 0),
+		choices: choices,
+	}
+	self.Box.AddCSSClass("editDialogRow")
+
+	selected := 0
+	for idx, choice := range choices {
+		if choice == value {
+			selected = idx
+		}
+	}
+
+	titleLabel := gtk.NewLabel(title)
+	titleLabel.SetHExpand(true)
+	titleLabel.SetHAlign(gtk.AlignStart)
+	self.Append(titleLabel)
+
+	self.dropDown = gtk.NewDropDownFromStrings(names)
+	self.dropDown.SetSelected(uint(selected))
+	self.Append(self.dropDown)
+
+	return
+}
+
+func (self *DialogChoiceRow[T]) Selected() T {
+	return self.choices[self.dropDown.Selected()]
+}
+
+func (self *DialogChoiceRow[T]) ConnectChanged(callback func()) {
+	self.dropDown.NotifyProperty("selected", callback)
+}
+
 type DialogTimeRow struct {
 	*gtk.Box
 	hours *TypedEntry[int]
@@ -230,6 +412,10 @@ func NewDialogRow[T EntryType](title string, value T) *DialogRow[T] {
 	return &DialogRow[T]{row, entry}
 }
 
+func (self *DialogRow[T]) Entry() *TypedEntry[T] {
+	return self.entry
+}
+
 type EntryType interface {
 	int | ~string
 }
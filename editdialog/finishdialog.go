@@ -0,0 +1,52 @@
+package editdialog
+
+import (
+	. "tallyGo/countable"
+	EventBus "tallyGo/eventBus"
+	"time"
+)
+
+// NewFinishDialog asks for the result of the hunt, finishing a counter
+// finishes its current phase. Only phases and counters can be finished
+func NewFinishDialog(countable Countable) *EditDialog {
+	this := newDialog(countable)
+	this.SetTitle("Shiny found")
+
+	var phase *Phase
+	species := ""
+	switch countable := countable.(type) {
+	case *Phase:
+		phase = countable
+	case *Counter:
+		phase = countable.Phases[len(countable.Phases)-1]
+		species = countable.Target.Species
+	}
+
+	this.NewRow("Outcome", OutcomeTarget)
+	this.NewRow("Species", species)
+	this.NewRow("Encounter", phase.GetEncounters())
+	this.NewRow("Nature", "")
+	this.NewRow("Ball", "")
+	this.NewRow("Notes", "")
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		result := Result{
+			Outcome:   this.rows["Outcome"].(Outcome),
+			Species:   this.rows["Species"].(string),
+			Encounter: this.rows["Encounter"].(int),
+			Date:      time.Now(),
+			Nature:    this.rows["Nature"].(string),
+			Ball:      this.rows["Ball"].(string),
+			Notes:     this.rows["Notes"].(string),
+		}
+		EventBus.GetGlobalBus().SendSignal(FinishPhase, phase, result)
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
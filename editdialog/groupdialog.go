@@ -0,0 +1,60 @@
+package editdialog
+
+import (
+	. "tallyGo/countable"
+)
+
+// NewMoveDialog lets the user pick the group a counter is shown in
+func NewMoveDialog(counter *Counter, list *CounterList) *EditDialog {
+	this := newDialog(counter)
+	this.SetTitle("Move to Group")
+
+	groups, names := []*Group{nil}, []string{"(no group)"}
+	current, _ := list.GetGroupOf(counter)
+	for _, group := range list.AllGroups() {
+		groups = append(groups, group)
+		names = append(names, groupPath(group, list))
+	}
+	row := NewDialogChoiceRow("Group", groups, names, current)
+	this.list.Append(row)
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		if group := row.Selected(); group != current {
+			list.MoveToGroup(counter, group)
+		}
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
+
+// NewGroupDialog asks for the name of a new group inside parent
+func NewGroupDialog(parent *Group, list *CounterList) *EditDialog {
+	this := newDialog(parent)
+	this.SetTitle("New Group")
+	this.NewRow("Name", "Group...")
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		list.NewGroup(this.rows["Name"].(string), parent)
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
+
+func groupPath(group *Group, list *CounterList) string {
+	if parent, ok := list.GetParent(group); ok && parent != nil {
+		return groupPath(parent, list) + " / " + group.Name
+	}
+	return group.Name
+}
@@ -0,0 +1,62 @@
+package editdialog
+
+import (
+	"fmt"
+	. "tallyGo/countable"
+	EventBus "tallyGo/eventBus"
+)
+
+// NewSplitDialog asks for the encounter the phase is split at, the phase
+// keeps the presses up to it and a new phase gets the rest
+func NewSplitDialog(phase *Phase) *EditDialog {
+	this := newDialog(phase)
+	this.SetTitle("Split Phase")
+	perPress := max(phase.EncountersPerPress, 1)
+	this.NewRow("Split at Encounter", phase.Count/2*perPress)
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		encounters := this.rows["Split at Encounter"].(int)
+		if count := encounters / perPress; count > 0 && count < phase.Count {
+			EventBus.GetGlobalBus().SendSignal(SplitPhase, phase, encounters)
+		}
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
+
+// NewMovePhaseDialog lets the user pick the counter the phase is moved to
+func NewMovePhaseDialog(phase *Phase, list *CounterList) *EditDialog {
+	this := newDialog(phase)
+	this.SetTitle("Move Phase")
+
+	current, ok := list.GetCounterOfPhase(phase)
+	if !ok {
+		return this
+	}
+	names := []string{}
+	for idx, counter := range list.List {
+		names = append(names, fmt.Sprintf("%d. %s", idx+1, counter.Name))
+	}
+	row := NewDialogChoiceRow("Counter", list.List, names, current)
+	this.list.Append(row)
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		if counter := row.Selected(); counter != current {
+			GetUndoStack().Do(NewMovePhaseToCounterCommand(current, counter, phase))
+		}
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
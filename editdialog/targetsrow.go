@@ -0,0 +1,148 @@
+package editdialog
+
+import (
+	"math"
+	. "tallyGo/countable"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// DialogTargetsRow edits the targets of a multi target hunt, every target
+// has a species, the percentage of encounters it appears in and its odds
+type DialogTargetsRow struct {
+	*gtk.Box
+	grid    *gtk.Grid
+	entries []*targetEntry
+
+	callbacks []func()
+}
+
+type targetEntry struct {
+	species *gtk.Entry
+	slot    *gtk.SpinButton
+	odds    *gtk.SpinButton
+}
+
+func NewDialogTargetsRow(title string, targets HuntTargets) (self *DialogTargetsRow) {
+	self = &DialogTargetsRow{
+		Box:  gtk.NewBox(gtk.OrientationVertical, 0),
+		grid: gtk.NewGrid(),
+	}
+	self.Box.AddCSSClass("editDialogRow")
+
+	titleLabel := gtk.NewLabel(title)
+	titleLabel.SetHAlign(gtk.AlignStart)
+	self.Append(titleLabel)
+
+	self.grid.SetColumnSpacing(6)
+	self.grid.SetRowSpacing(4)
+	self.grid.Attach(gtk.NewLabel("Species"), 0, 0, 1, 1)
+	self.grid.Attach(gtk.NewLabel("Slot %"), 1, 0, 1, 1)
+	self.grid.Attach(gtk.NewLabel("Odds 1/"), 2, 0, 1, 1)
+	self.Append(self.grid)
+
+	for _, target := range targets {
+		self.addTarget(target)
+	}
+
+	addButton := gtk.NewButtonWithLabel("add target")
+	addButton.SetHAlign(gtk.AlignEnd)
+	addButton.ConnectClicked(func() {
+		odds := 4096.0
+		if len(self.entries) > 0 {
+			odds = self.entries[len(self.entries)-1].odds.Value()
+		}
+		self.addTarget(HuntTarget{Species: "", Slot: self.freeSlot(1), Odds: odds})
+		self.changed()
+	})
+	self.Append(addButton)
+
+	return
+}
+
+func (self *DialogTargetsRow) addTarget(target HuntTarget) {
+	entry := &targetEntry{
+		species: gtk.NewEntry(),
+		slot:    gtk.NewSpinButtonWithRange(0.1, 100, 0.1),
+		odds:    gtk.NewSpinButtonWithRange(2, 1<<20, 1),
+	}
+	entry.species.SetText(target.Species)
+	entry.species.SetMaxWidthChars(12)
+	entry.slot.SetDigits(1)
+	entry.slot.SetValue(target.Slot)
+	entry.odds.SetValue(target.Odds)
+
+	removeButton := gtk.NewButtonFromIconName("list-remove-symbolic")
+	removeButton.ConnectClicked(func() {
+		self.removeTarget(entry)
+	})
+
+	row := len(self.entries) + 1
+	for column, widget := range []gtk.Widgetter{entry.species, entry.slot, entry.odds, removeButton} {
+		self.grid.Attach(widget, column, row, 1, 1)
+	}
+	self.entries = append(self.entries, entry)
+
+	entry.species.ConnectChanged(self.changed)
+	entry.slot.ConnectValueChanged(self.changed)
+	entry.odds.ConnectValueChanged(self.changed)
+}
+
+// removeTarget moves the rows below the removed target up, the last target
+// can't be removed
+func (self *DialogTargetsRow) removeTarget(entry *targetEntry) {
+	if len(self.entries) <= 1 {
+		return
+	}
+	for idx, e := range self.entries {
+		if e == entry {
+			self.grid.RemoveRow(idx + 1)
+			self.entries = append(self.entries[:idx], self.entries[idx+1:]...)
+			break
+		}
+	}
+	self.changed()
+}
+
+// freeSlot returns up to want percent that no target uses yet, without room
+// left the target with the largest slot gives the percentage up
+func (self *DialogTargetsRow) freeSlot(want float64) float64 {
+	free := 100.0
+	var largest *targetEntry
+	for _, entry := range self.entries {
+		free -= entry.slot.Value()
+		if largest == nil || entry.slot.Value() > largest.slot.Value() {
+			largest = entry
+		}
+	}
+	if free >= want || largest == nil {
+		return want
+	}
+	if free >= 0.1 {
+		return math.Floor(free*10) / 10
+	}
+	want = math.Min(want, largest.slot.Value()/2)
+	largest.slot.SetValue(largest.slot.Value() - want)
+	return want
+}
+
+func (self *DialogTargetsRow) changed() {
+	for _, f := range self.callbacks {
+		f()
+	}
+}
+
+func (self *DialogTargetsRow) ConnectChanged(callback func()) {
+	self.callbacks = append(self.callbacks, callback)
+}
+
+func (self *DialogTargetsRow) Targets() (targets HuntTargets) {
+	for _, entry := range self.entries {
+		targets = append(targets, HuntTarget{
+			Species: entry.species.Text(),
+			Slot:    entry.slot.Value(),
+			Odds:    entry.odds.Value(),
+		})
+	}
+	return
+}
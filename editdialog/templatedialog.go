@@ -0,0 +1,52 @@
+package editdialog
+
+import (
+	. "tallyGo/countable"
+)
+
+// NewTemplateDialog edits the template, confirmed is called after the
+// template was changed
+func NewTemplateDialog(template *Template, confirmed func()) *EditDialog {
+	this := newDialog(nil)
+	this.SetTitle("Edit Template")
+
+	this.NewRow("Name", template.Name)
+	this.NewRow("HuntType", template.ProgressType)
+	this.NewRow("Game", template.Game)
+	this.NewRow("Shiny Charm", template.HasCharm)
+	for _, option := range template.AllOptions() {
+		this.NewRow(option.Name, option.Value)
+	}
+
+	this.AddButton("cancel", func() {
+		this.Close()
+	})
+	this.AddButton("confirm", func() {
+		options := template.AllOptions()
+		if !this.validOptions(options, template.ValidateOption) {
+			return
+		}
+		for idx, option := range options {
+			if value, ok := this.rows[option.Name]; ok {
+				options[idx].Value = value
+			}
+		}
+		huntType := this.rows["HuntType"].(ProgressType)
+		if huntType != template.ProgressType {
+			// options of another method can have the same name but another type
+			options = options[:1]
+		}
+
+		template.Name = this.rows["Name"].(string)
+		template.ProgressType = huntType
+		template.Game = this.rows["Game"].(Game)
+		template.HasCharm = this.rows["Shiny Charm"].(bool)
+		template.SetOptions(options)
+		confirmed()
+		this.Close()
+	})
+
+	this.appendButtonRow()
+
+	return this
+}
@@ -22,27 +22,54 @@ func NewEvent(kind Signal, data ...interface{}) Event {
 }
 
 type EventBus struct {
-	callbacks map[Signal][]func(args ...interface{})
+	callbacks map[Signal][]subscriber
+	nextID    int
+}
+
+type subscriber struct {
+	id       int
+	callback func(args ...interface{})
+}
+
+// Subscription identifies a callback so it can be unsubscribed again
+type Subscription struct {
+	kind Signal
+	id   int
 }
 
 func NewEventBus() (self *EventBus) {
 	return &EventBus{
-		callbacks: map[Signal][]func(args ...interface{}){},
+		callbacks: map[Signal][]subscriber{},
 	}
 }
 
 func (self *EventBus) SendSignal(signal Signal, args ...interface{}) {
-	for _, f := range self.callbacks[signal] {
-		f(args...)
+	for _, s := range self.callbacks[signal] {
+		s.callback(args...)
 	}
 }
 
 func (self *EventBus) Send(event Event) {
-	for _, f := range self.callbacks[event.kind] {
-		f(event.data...)
+	for _, s := range self.callbacks[event.kind] {
+		s.callback(event.data...)
 	}
 }
 
-func (self *EventBus) Subscribe(kind Signal, callback func(...interface{})) {
-	self.callbacks[kind] = append(self.callbacks[kind], callback)
+func (self *EventBus) Subscribe(kind Signal, callback func(...interface{})) Subscription {
+	self.nextID++
+	self.callbacks[kind] = append(self.callbacks[kind], subscriber{self.nextID, callback})
+	return Subscription{kind, self.nextID}
+}
+
+// Unsubscribe removes the callback, a signal that is being sent still calls
+// it this one time
+func (self *EventBus) Unsubscribe(subscription Subscription) {
+	subscribers := self.callbacks[subscription.kind]
+	for idx, s := range subscribers {
+		if s.id == subscription.id {
+			// a new slice so a signal that is being sent is not changed
+			self.callbacks[subscription.kind] = append(append([]subscriber{}, subscribers[:idx]...), subscribers[idx+1:]...)
+			return
+		}
+	}
 }
@@ -2,13 +2,10 @@ module tallyGo
 
 go 1.21
 
-
-
 require (
 	github.com/diamondburned/gotk4-adwaita/pkg v0.0.0-20230307040502-bfe82926e1be
-    gonum.org/v1/gonum v0.13.0
-    golang.org/x/exp v0.0.0-20230811145659-89c5cff77bcb
-    github.com/diamondburned/gotk4/pkg v0.0.5
+	github.com/diamondburned/gotk4/pkg v0.0.5
+	golang.org/x/exp v0.0.0-20230811145659-89c5cff77bcb
 )
 
 require (
@@ -162,6 +162,13 @@ type CodeType interface {
 
 type KeyType uint16
 
+func (self KeyType) String() string {
+	if name, ok := evdev.KEY[int(self)]; ok {
+		return strings.TrimPrefix(name, "KEY_")
+	}
+	return "UNKNOWN"
+}
+
 func (self KeyType) GetCode() uint16 {
 	return uint16(self)
 }
@@ -6,11 +6,16 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"tallyGo/archive"
 	. "tallyGo/countable"
+	"tallyGo/editdialog"
 	EventBus "tallyGo/eventBus"
 	"tallyGo/input"
+	"tallyGo/planner"
 	"tallyGo/resizebar"
 	"tallyGo/settings"
+	"tallyGo/statistics"
 	"tallyGo/treeview"
 	"time"
 
@@ -39,8 +44,6 @@ var CSS_DARK string
 var APP *adw.Application
 var HOME *HomeApplicationWindow
 
-// TODO: instead of just storing the date counter should store diffs with a time
-// this will improve the info window
 func main() {
 	APP = adw.NewApplication("com.github.p3rtang.counter", gio.ApplicationFlagsNone)
 	APP.ConnectActivate(func() { activate(APP) })
@@ -78,12 +81,20 @@ type HomeApplicationWindow struct {
 	homeGrid     *gtk.Grid
 	settings     *settings.Settings
 	settingsGrid *settings.SettingsMenu
+	planner      *planner.Planner
+	luckPage     *statistics.LuckPage
+	archivePage  *archive.ArchivePage
 	infoBox      *infoBox
 
 	treeViewRevealer     *gtk.Revealer
 	isRevealerAutoHidden bool
 	collapseButton       *gtk.Button
+	undoButton           *gtk.Button
+	redoButton           *gtk.Button
 	settingsButton       *gtk.ToggleButton
+	plannerButton        *gtk.ToggleButton
+	statisticsButton     *gtk.ToggleButton
+	archiveButton        *gtk.ToggleButton
 	headerBar            *gtk.HeaderBar
 	isTimingActive       bool
 }
@@ -95,10 +106,18 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 		gtk.NewGrid(),
 		nil,
 		nil,
+		planner.NewPlanner(),
+		nil,
+		nil,
 		nil,
 		gtk.NewRevealer(),
 		false,
 		gtk.NewButtonFromIconName("sidebar-show-symbolic"),
+		gtk.NewButtonFromIconName("edit-undo-symbolic"),
+		gtk.NewButtonFromIconName("edit-redo-symbolic"),
+		gtk.NewToggleButton(),
+		gtk.NewToggleButton(),
+		gtk.NewToggleButton(),
 		gtk.NewToggleButton(),
 		gtk.NewHeaderBar(),
 		false,
@@ -120,22 +139,46 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 	self.settingsGrid = settings.NewSettingsMenu(self.settings)
 	self.settingsGrid.AddItem(settings.Keyboard)
 	self.settingsGrid.AddItem(settings.Theme)
+	self.settingsGrid.AddItem(settings.Templates)
 
-	counters := NewCounterList(saveDataHandler.CounterData)
-	app.ConnectShutdown(func() {
+	counters := NewCounterList(saveDataHandler.CounterData, saveDataHandler.GroupData, saveDataHandler.ArchiveData)
+	saveCounters := func(...interface{}) {
 		saveDataHandler.CounterData = counters.List
+		saveDataHandler.GroupData = counters.Groups
 		saveDataHandler.Save()
+	}
+	saveArchive := func(...interface{}) {
+		saveCounters()
+		saveDataHandler.ArchiveData = counters.Archive
+		saveDataHandler.SaveArchive()
+	}
+	app.ConnectShutdown(func() {
+		saveArchive()
+	})
+	eventBus.Subscribe(GroupsChanged, saveCounters)
+	eventBus.Subscribe(CounterArchived, saveArchive)
+	eventBus.Subscribe(CounterRestored, saveArchive)
+	eventBus.Subscribe(SaveTemplate, func(args ...interface{}) {
+		self.settings.AddTemplate(NewTemplateFromCounter(args[0].(*Counter)))
+	})
+	self.settings.ConnectChanged(settings.TemplateList, func(any) {
+		saveCounters()
 	})
 
-	counterTV := treeview.NewCounterTreeView(counters)
+	counterTV := treeview.NewCounterTreeView(counters, self.settings)
 
 	scrollView := gtk.NewScrolledWindow()
 	scrollView.SetPropagateNaturalWidth(true)
 	scrollView.SetChild(counterTV)
 	scrollView.SetName("treeViewScrollWindow")
+	scrollView.SetVExpand(true)
+
+	treeViewBox := gtk.NewBox(gtk.OrientationVertical, 0)
+	treeViewBox.Append(counterTV.Header())
+	treeViewBox.Append(scrollView)
 
 	self.treeViewRevealer.SetTransitionType(gtk.RevealerTransitionTypeSlideRight)
-	self.treeViewRevealer.SetChild(scrollView)
+	self.treeViewRevealer.SetChild(treeViewBox)
 	self.treeViewRevealer.SetRevealChild(true)
 
 	self.Window.ConnectShow(func() {
@@ -153,16 +196,37 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 	self.settingsButton.SetName("settingsButton")
 	image := self.settingsButton.Child().(*gtk.Image)
 	image.SetPixelSize(18)
-	self.settingsButton.ConnectToggled(func() {
-		if self.settingsButton.Active() {
-			self.overlay.SetChild(self.settingsGrid)
-		} else {
-			self.overlay.SetChild(self.homeGrid)
-		}
+	self.plannerButton.SetIconName("accessories-calculator-symbolic")
+	self.plannerButton.SetTooltipText("Hunt Planner")
+	self.statisticsButton.SetIconName("utilities-system-monitor-symbolic")
+	self.statisticsButton.SetTooltipText("Luck Statistics")
+	self.archiveButton.SetIconName("folder-symbolic")
+	self.archiveButton.SetTooltipText("Archive")
+
+	self.luckPage = statistics.NewLuckPage(counters)
+	self.connectPageButton(self.settingsButton, self.settingsGrid)
+	self.connectPageButton(self.plannerButton, self.planner)
+	self.connectPageButton(self.statisticsButton, self.luckPage)
+	self.archivePage = archive.NewArchivePage(counters)
+	self.connectPageButton(self.archiveButton, self.archivePage)
+
+	self.undoButton.SetTooltipText("Undo")
+	self.undoButton.ConnectClicked(func() { GetUndoStack().Undo() })
+	self.redoButton.SetTooltipText("Redo")
+	self.redoButton.ConnectClicked(func() { GetUndoStack().Redo() })
+	self.setUndoSensitive()
+	eventBus.Subscribe(UndoStackChanged, func(...interface{}) {
+		self.setUndoSensitive()
+		saveCounters()
 	})
 
 	self.headerBar.PackStart(self.collapseButton)
+	self.headerBar.PackStart(self.undoButton)
+	self.headerBar.PackStart(self.redoButton)
 	self.headerBar.PackEnd(self.settingsButton)
+	self.headerBar.PackEnd(self.plannerButton)
+	self.headerBar.PackEnd(self.statisticsButton)
+	self.headerBar.PackEnd(self.archiveButton)
 	self.SetTitlebar(self.headerBar)
 
 	self.SetChild(self.overlay)
@@ -231,7 +295,17 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 
 	eventController := gtk.NewEventControllerKey()
 	self.Window.AddController(eventController)
-	eventController.ConnectKeyReleased(func(keyval uint, _ uint, _ gdk.ModifierType) {
+	eventController.ConnectKeyReleased(func(keyval uint, _ uint, state gdk.ModifierType) {
+		if state&gdk.ControlMask != 0 {
+			switch {
+			case keyval == gdk.KEY_z && state&gdk.ShiftMask == 0:
+				GetUndoStack().Undo()
+			case keyval == gdk.KEY_Z || keyval == gdk.KEY_z || keyval == gdk.KEY_y:
+				GetUndoStack().Redo()
+			}
+			return
+		}
+
 		var key input.KeyType
 		switch keyval {
 		case 112:
@@ -246,7 +320,7 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 			time.Sleep(FRAME_TIME)
 			if self.isTimingActive && counters.HasActive() {
 				glib.IdleAdd(func() {
-					for _, countable := range counters.GetActive() {
+					for _, countable := range countedActive(counters) {
 						countable.AddTime(time.Now().Sub(startInstant))
 					}
 				})
@@ -257,28 +331,69 @@ func newHomeApplicationWindow(app *adw.Application) (self *HomeApplicationWindow
 	eventBus.Subscribe(input.DevKeyReleased, func(args ...interface{}) {
 		key := args[0].(input.KeyType)
 
+		if settings.ConsumeKey(key) {
+			return
+		}
+		if self.isTimingActive && self.increaseBound(counters, key) {
+			return
+		}
+
 		switch {
 		case key == input.KeyEqual || key == input.KeyKeypadPlus:
 			if !self.isTimingActive {
 				return
 			}
 			glib.IdleAdd(func() {
-				for _, countable := range counters.GetActive() {
-					countable.IncreaseBy(1)
-				}
+				increase(countedActive(counters), 1)
 			})
-			saveDataHandler.Save()
 
 		case key == input.KeyMinus || key == input.KeyKeypadMinus:
 			if !self.isTimingActive {
 				return
 			}
 			glib.IdleAdd(func() {
-				for _, countable := range counters.GetActive() {
-					countable.IncreaseBy(-1)
+				increase(countedActive(counters), -1)
+			})
+
+		case key == self.settings.GetKey(settings.FinishKey, settings.DefaultFinishKey):
+			if !self.isTimingActive {
+				return
+			}
+			glib.IdleAdd(func() {
+				for _, countable := range countedActive(counters) {
+					editdialog.NewFinishDialog(countable).Show()
+				}
+			})
+
+		case key == self.settings.GetKey(settings.UndoKey, settings.DefaultUndoKey):
+			if !self.isTimingActive {
+				return
+			}
+			glib.IdleAdd(func() {
+				GetUndoStack().Undo()
+			})
+
+		case key == self.settings.GetKey(settings.RedoKey, settings.DefaultRedoKey):
+			if !self.isTimingActive {
+				return
+			}
+			glib.IdleAdd(func() {
+				GetUndoStack().Redo()
+			})
+
+		case key == self.settings.GetKey(settings.BreakChainKey, settings.DefaultBreakChainKey):
+			if !self.isTimingActive {
+				return
+			}
+			glib.IdleAdd(func() {
+				breaks := CommandGroup{}
+				for _, countable := range countedActive(counters) {
+					breaks = append(breaks, NewBreakChainCommand(countable))
+				}
+				if len(breaks) > 0 {
+					GetUndoStack().Do(breaks)
 				}
 			})
-			saveDataHandler.Save()
 
 		case key == input.KeyQ:
 			self.isTimingActive = false
@@ -344,6 +459,94 @@ func (self *HomeApplicationWindow) setCSSTheme(css *gtk.CSSProvider) {
 	}
 }
 
+// connectPageButton shows the page instead of the home grid while the button
+// is active, only one page is shown at a time
+func (self *HomeApplicationWindow) connectPageButton(button *gtk.ToggleButton, page gtk.Widgetter) {
+	button.ConnectToggled(func() {
+		pageButtons := []*gtk.ToggleButton{self.settingsButton, self.plannerButton, self.statisticsButton, self.archiveButton}
+		if button.Active() {
+			for _, other := range pageButtons {
+				if other != button {
+					other.SetActive(false)
+				}
+			}
+			self.overlay.SetChild(page)
+			return
+		}
+		for _, other := range pageButtons {
+			if other.Active() {
+				return
+			}
+		}
+		self.overlay.SetChild(self.homeGrid)
+	})
+}
+
+func (self *HomeApplicationWindow) setUndoSensitive() {
+	self.undoButton.SetSensitive(GetUndoStack().CanUndo())
+	self.redoButton.SetSensitive(GetUndoStack().CanRedo())
+}
+
+// increaseBound changes the active countables whose counter binds key, the
+// keys of templates only apply to counters made from them
+func (self *HomeApplicationWindow) increaseBound(counters *CounterList, key input.KeyType) bool {
+	increased, decreased := []Countable{}, []Countable{}
+	for _, countable := range counters.GetActive() {
+		counter, ok := countable.(*Counter)
+		if phase, isPhase := countable.(*Phase); isPhase {
+			counter, ok = counters.GetCounterOfPhase(phase)
+		}
+		if !ok {
+			continue
+		}
+		switch int(key) {
+		case counter.Keys.Increase:
+			increased = append(increased, countable)
+		case counter.Keys.Decrease:
+			decreased = append(decreased, countable)
+		}
+	}
+	if len(increased) == 0 && len(decreased) == 0 {
+		return false
+	}
+
+	glib.IdleAdd(func() {
+		commands := CommandGroup{}
+		for _, countable := range increased {
+			commands = append(commands, NewIncreaseCommand(countable, 1))
+		}
+		for _, countable := range decreased {
+			commands = append(commands, NewIncreaseCommand(countable, -1))
+		}
+		GetUndoStack().Do(commands)
+	})
+	return true
+}
+
+// countedActive returns the active counters and phases, selected groups
+// only show the totals of their counters and can't be counted
+func countedActive(counters *CounterList) (countables []Countable) {
+	for _, countable := range counters.GetActive() {
+		switch countable.(type) {
+		case *Counter, *Phase:
+			countables = append(countables, countable)
+		}
+	}
+	return
+}
+
+// increase changes all active countables in a single undo step
+func increase(countables []Countable, add int) {
+	if len(countables) == 0 {
+		return
+	}
+	commands := CommandGroup{}
+	for _, countable := range countables {
+		commands = append(commands, NewIncreaseCommand(countable, add))
+	}
+	GetUndoStack().Do(commands)
+}
+
 func (self *HomeApplicationWindow) HandleNotify() {
 }
 
@@ -355,8 +558,13 @@ const (
 )
 
 type SaveFileHandler struct {
-	filePath     string
-	CounterData  []*Counter
+	filePath    string
+	CounterData []*Counter
+	GroupData   []*Group
+	// finished hunts are saved to their own file, it is only written when a
+	// counter is archived or restored so the save of the active counters
+	// stays small
+	ArchiveData  []*Counter `json:"-"`
 	SettingsData *settings.Settings
 
 	strategy SaveStrategy
@@ -367,10 +575,16 @@ func NewSaveFileHandler(path string, strategy SaveStrategy) *SaveFileHandler {
 		path,
 		nil,
 		nil,
+		nil,
+		nil,
 		strategy,
 	}
 }
 
+func (self *SaveFileHandler) archivePath() string {
+	return filepath.Join(filepath.Dir(self.filePath), "Archive.json")
+}
+
 func (self *SaveFileHandler) Save() (err error) {
 	var saveData []byte
 	if saveData, err = json.Marshal(self); err != nil {
@@ -380,6 +594,15 @@ func (self *SaveFileHandler) Save() (err error) {
 	return
 }
 
+func (self *SaveFileHandler) SaveArchive() (err error) {
+	var archiveData []byte
+	if archiveData, err = json.Marshal(self.ArchiveData); err != nil {
+		return
+	}
+	os.WriteFile(self.archivePath(), archiveData, 0666)
+	return
+}
+
 func (self *SaveFileHandler) Restore() (err error) {
 	var saveData []byte
 	if saveData, err = os.ReadFile(self.filePath); err != nil {
@@ -403,5 +626,12 @@ func (self *SaveFileHandler) Restore() (err error) {
 
 	log.Printf("[INFO]\tLoaded %d Counters\n", len(self.CounterData))
 
+	if archiveData, err := os.ReadFile(self.archivePath()); err == nil {
+		if err = json.Unmarshal(archiveData, &self.ArchiveData); err != nil {
+			log.Println("[WARN]\tCould not Unmarshal the archive, Got Error: ", err)
+		}
+	}
+	log.Printf("[INFO]\tLoaded %d archived Counters\n", len(self.ArchiveData))
+
 	return
 }
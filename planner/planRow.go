@@ -0,0 +1,123 @@
+package planner
+
+import (
+	"tallyGo/countable"
+	"tallyGo/editdialog"
+	"time"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// PlanRow edits a single plan, the option rows follow the selected method
+// and game
+type PlanRow struct {
+	*gtk.Box
+
+	name      *editdialog.DialogRow[string]
+	method    *editdialog.DialogChoiceRow[countable.ProgressType]
+	game      *editdialog.DialogChoiceRow[countable.Game]
+	charm     *editdialog.DialogBoolRow
+	seconds   *editdialog.DialogRow[int]
+	optionBox *gtk.Box
+	values    []optionValue
+	remove    *gtk.Button
+}
+
+func NewPlanRow(plan countable.Plan) (self *PlanRow) {
+	types, typeNames := []countable.ProgressType{}, []string{}
+	for _, method := range countable.Methods() {
+		types = append(types, method.Type)
+		typeNames = append(typeNames, method.DisplayName)
+	}
+	gameNames := []string{}
+	for _, game := range countable.Games() {
+		gameNames = append(gameNames, game.String())
+	}
+
+	self = &PlanRow{
+		gtk.NewBox(gtk.OrientationVertical, 0),
+		editdialog.NewDialogRow("Name", plan.Name),
+		editdialog.NewDialogChoiceRow("Method", types, typeNames, plan.Type),
+		editdialog.NewDialogChoiceRow("Game", countable.Games(), gameNames, plan.Game),
+		editdialog.NewDialogBoolRow("Shiny Charm", plan.HasCharm),
+		editdialog.NewDialogRow("Seconds per Press", int(plan.TimePerPress.Seconds())),
+		gtk.NewBox(gtk.OrientationVertical, 0),
+		[]optionValue{},
+		gtk.NewButtonWithLabel("remove"),
+	}
+
+	self.AddCSSClass("plannerPlanRow")
+	self.Append(self.name)
+	self.Append(self.method)
+	self.Append(self.game)
+	self.Append(self.charm)
+	self.Append(self.seconds)
+	self.Append(self.optionBox)
+	self.Append(self.remove)
+	self.remove.SetHAlign(gtk.AlignEnd)
+
+	self.setOptions(plan.Options)
+	self.method.ConnectChanged(func() { self.setOptions(self.Options()) })
+	self.game.ConnectChanged(func() { self.setOptions(self.Options()) })
+
+	return
+}
+
+func (self *PlanRow) ConnectRemove(callback func()) {
+	self.remove.ConnectClicked(callback)
+}
+
+// setOptions shows the options of the current method and game, values of
+// options that still exist are kept
+func (self *PlanRow) setOptions(values []countable.Option) {
+	for child := self.optionBox.FirstChild(); child != nil; child = self.optionBox.FirstChild() {
+		self.optionBox.Remove(child)
+	}
+	self.values = []optionValue{}
+
+	phase := countable.NewPlanPhase(countable.Plan{
+		Type:    self.method.Selected(),
+		Game:    self.game.Selected(),
+		Options: values,
+	})
+	for _, option := range phase.Options() {
+		switch value := option.Value.(type) {
+		case int:
+			row := editdialog.NewDialogRow(option.Name, value)
+			self.optionBox.Append(row)
+			self.values = append(self.values, optionValue{option.Name, func() interface{} { return int(row.Entry().Int()) }})
+		case string:
+			row := editdialog.NewDialogRow(option.Name, value)
+			self.optionBox.Append(row)
+			self.values = append(self.values, optionValue{option.Name, func() interface{} { return row.Entry().Text() }})
+		case bool:
+			row := editdialog.NewDialogBoolRow(option.Name, value)
+			self.optionBox.Append(row)
+			self.values = append(self.values, optionValue{option.Name, func() interface{} { return row.Active() }})
+		}
+	}
+}
+
+// optionValue reads the current value of an option row
+type optionValue struct {
+	name  string
+	value func() interface{}
+}
+
+func (self *PlanRow) Options() (options []countable.Option) {
+	for _, option := range self.values {
+		options = append(options, countable.Option{Name: option.name, Value: option.value()})
+	}
+	return
+}
+
+func (self *PlanRow) Plan() countable.Plan {
+	return countable.Plan{
+		Name:         self.name.Entry().Text(),
+		Type:         self.method.Selected(),
+		Game:         self.game.Selected(),
+		HasCharm:     self.charm.Active(),
+		Options:      self.Options(),
+		TimePerPress: time.Duration(self.seconds.Entry().Int()) * time.Second,
+	}
+}
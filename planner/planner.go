@@ -0,0 +1,152 @@
+package planner
+
+import (
+	"fmt"
+	"tallyGo/countable"
+	"tallyGo/editdialog"
+	"time"
+
+	"github.com/diamondburned/gotk4/pkg/glib/v2"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+const (
+	defaultRuns = 10000
+	defaultSeed = 1
+)
+
+// Planner compares hunt setups by simulating them side by side
+type Planner struct {
+	*gtk.ScrolledWindow
+
+	plans   *gtk.Box
+	rows    []*PlanRow
+	runs    *editdialog.DialogRow[int]
+	seed    *editdialog.DialogRow[int]
+	results *gtk.Grid
+	button  *gtk.Button
+}
+
+func NewPlanner() (self *Planner) {
+	self = &Planner{
+		gtk.NewScrolledWindow(),
+		gtk.NewBox(gtk.OrientationHorizontal, 0),
+		[]*PlanRow{},
+		editdialog.NewDialogRow("Runs", defaultRuns),
+		editdialog.NewDialogRow("Seed", defaultSeed),
+		gtk.NewGrid(),
+		gtk.NewButtonWithLabel("simulate"),
+	}
+
+	box := gtk.NewBox(gtk.OrientationVertical, 0)
+	box.AddCSSClass("plannerBox")
+	self.SetChild(box)
+
+	addButton := gtk.NewButtonWithLabel("add plan")
+	addButton.ConnectClicked(func() {
+		self.AddPlan(countable.Plan{Name: "Plan", Type: countable.NewOdds, TimePerPress: 10 * time.Second})
+	})
+
+	controls := gtk.NewBox(gtk.OrientationHorizontal, 0)
+	controls.AddCSSClass("plannerControls")
+	controls.Append(self.runs)
+	controls.Append(self.seed)
+	controls.Append(addButton)
+	controls.Append(self.button)
+
+	box.Append(controls)
+	box.Append(self.plans)
+	box.Append(self.results)
+
+	self.results.AddCSSClass("plannerResults")
+	self.results.SetColumnSpacing(24)
+	self.button.ConnectClicked(self.Simulate)
+
+	self.AddPlan(countable.Plan{
+		Name:         "Full Odds",
+		Type:         countable.FullOdds,
+		Game:         countable.SwSh,
+		TimePerPress: 12 * time.Second,
+	})
+	self.AddPlan(countable.Plan{
+		Name:         "SOS",
+		Type:         countable.SOS,
+		Game:         countable.Gen7,
+		HasCharm:     true,
+		TimePerPress: 40 * time.Second,
+	})
+
+	return
+}
+
+func (self *Planner) AddPlan(plan countable.Plan) {
+	row := NewPlanRow(plan)
+	row.ConnectRemove(func() {
+		for idx, r := range self.rows {
+			if r == row {
+				self.rows = append(self.rows[:idx], self.rows[idx+1:]...)
+			}
+		}
+		self.plans.Remove(row)
+	})
+	self.rows = append(self.rows, row)
+	self.plans.Append(row)
+}
+
+// Simulate runs every plan in the background and shows the results when
+// all are done
+func (self *Planner) Simulate() {
+	plans := []countable.Plan{}
+	for _, row := range self.rows {
+		plans = append(plans, row.Plan())
+	}
+	runs := max(int(self.runs.Entry().Int()), 1)
+	seed := self.seed.Entry().Int()
+
+	self.button.SetSensitive(false)
+	go func() {
+		results := []countable.SimulationResult{}
+		for _, plan := range plans {
+			results = append(results, countable.Simulate(plan, runs, seed))
+		}
+		glib.IdleAdd(func() {
+			self.showResults(results)
+			self.button.SetSensitive(true)
+		})
+	}()
+}
+
+func (self *Planner) showResults(results []countable.SimulationResult) {
+	for child := self.results.FirstChild(); child != nil; child = self.results.FirstChild() {
+		self.results.Remove(child)
+	}
+
+	header := []string{"Plan", "Mean"}
+	for _, percentile := range countable.StatPercentiles {
+		header = append(header, fmt.Sprintf("%.0f%%", percentile*100))
+	}
+	header = append(header, "Median Encounters")
+	for column, title := range header {
+		label := gtk.NewLabel(title)
+		label.SetName("title")
+		self.results.Attach(label, column, 0, 1, 1)
+	}
+
+	for row, result := range results {
+		values := []string{result.Plan.Name, formatDuration(result.MeanTime())}
+		for _, percentile := range countable.StatPercentiles {
+			values = append(values, formatDuration(result.TimeAt(percentile)))
+		}
+		values = append(values, fmt.Sprintf("%d", result.EncountersAt(.5)))
+		for column, value := range values {
+			self.results.Attach(gtk.NewLabel(value), column, row+1, 1, 1)
+		}
+	}
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Hour {
+		return fmt.Sprintf("%dm %02ds", int(duration.Minutes()), int(duration.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh %02dm", int(duration.Hours()), int(duration.Minutes())%60)
+}
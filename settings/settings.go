@@ -1,6 +1,7 @@
 package settings
 
 import (
+	"tallyGo/countable"
 	"tallyGo/input"
 
 	"github.com/diamondburned/gotk4/pkg/core/glib"
@@ -9,7 +10,9 @@ import (
 )
 
 type Settings struct {
-	Items     map[SettingsKey]any
+	Items map[SettingsKey]any
+	// presets for new counters, offered by the "New Counter" button
+	Templates []*countable.Template
 	callbacks map[SettingsKey][]func(value any)
 }
 
@@ -19,7 +22,7 @@ func NewSettings() *Settings {
 
 	callbacks := map[SettingsKey][]func(value any){}
 
-	this := &Settings{items, callbacks}
+	this := &Settings{items, []*countable.Template{}, callbacks}
 	return this
 }
 
@@ -45,6 +48,28 @@ func (self *Settings) SetValue(key SettingsKey, value any) {
 	}
 }
 
+func (self *Settings) AddTemplate(template *countable.Template) {
+	self.Templates = append(self.Templates, template)
+	self.TemplatesChanged()
+}
+
+// TemplatesChanged has to be called after a template was edited
+func (self *Settings) TemplatesChanged() {
+	for _, f := range self.callbacks[TemplateList] {
+		f(self.Templates)
+	}
+}
+
+func (self *Settings) RemoveTemplate(template *countable.Template) {
+	for idx, t := range self.Templates {
+		if t == template {
+			self.Templates = append(self.Templates[:idx], self.Templates[idx+1:]...)
+			self.TemplatesChanged()
+			return
+		}
+	}
+}
+
 type SettingsKey uint
 
 const (
@@ -52,8 +77,27 @@ const (
 	ActiveKeyboard
 	DarkMode
 	SideBarSize
+	UndoKey
+	RedoKey
+	FinishKey
+	TreeViewFilter
+	TreeViewSort
+	// not stored in Items, sent when the templates changed
+	TemplateList
+	BreakChainKey
 )
 
+// GetKey returns a key binding, keys are numbers after loading the save file
+func (self *Settings) GetKey(key SettingsKey, fallback input.KeyType) input.KeyType {
+	switch value := self.Items[key].(type) {
+	case input.KeyType:
+		return value
+	case float64:
+		return input.KeyType(value)
+	}
+	return fallback
+}
+
 type SettingsMenu struct {
 	*gtk.Box
 
@@ -123,6 +167,8 @@ func (self *SettingsItems) selection() (key SettingsItemKey) {
 		key = Keyboard
 	case 1:
 		key = Theme
+	case 2:
+		key = Templates
 	}
 	return
 }
@@ -135,14 +181,17 @@ func (self SettingsItemKey) menuGrid(settings *Settings) SettingsItemGrid {
 		return NewKeyboardSettingsGrid(settings)
 	case Theme:
 		return NewThemeSettingsGrid(settings)
+	case Templates:
+		return NewTemplatesSettingsGrid(settings)
 	}
 
 	return nil
 }
 
 const (
-	Keyboard SettingsItemKey = "Keyboard"
-	Theme                    = "Theme"
+	Keyboard  SettingsItemKey = "Keyboard"
+	Theme                     = "Theme"
+	Templates                 = "Templates"
 )
 
 type SettingsItemGrid interface {
@@ -159,7 +208,25 @@ func NewKeyboardSettingsGrid(settings *Settings) *KeyboardSettingsGrid {
 	box.Append(chooser)
 
 	grid := gtk.NewGrid()
-	grid.Attach(box, 0, 0, 1, 1)
+	grid.SetRowSpacing(6)
+	grid.SetColumnSpacing(12)
+	grid.Attach(box, 0, 0, 2, 1)
+
+	for idx, binding := range []struct {
+		name     string
+		key      SettingsKey
+		fallback input.KeyType
+	}{
+		{"Undo", UndoKey, DefaultUndoKey},
+		{"Redo", RedoKey, DefaultRedoKey},
+		{"Shiny Found", FinishKey, DefaultFinishKey},
+		{"Break Chain", BreakChainKey, DefaultBreakChainKey},
+	} {
+		label := gtk.NewLabel(binding.name)
+		label.SetHAlign(gtk.AlignStart)
+		grid.Attach(label, 0, idx+1, 1, 1)
+		grid.Attach(NewKeyBindButton(settings, binding.key, binding.fallback), 1, idx+1, 1, 1)
+	}
 
 	this := KeyboardSettingsGrid{grid}
 
@@ -174,6 +241,59 @@ func (self *KeyboardSettingsGrid) grid() *gtk.Grid {
 	return self.Grid
 }
 
+const (
+	DefaultUndoKey       = input.KeyU
+	DefaultRedoKey       = input.KeyR
+	DefaultFinishKey     = input.KeyF
+	DefaultBreakChainKey = input.KeyB
+)
+
+// KeyBindButton changes a key binding to the next key released on the
+// active keyboard after it is clicked
+type KeyBindButton struct {
+	*gtk.Button
+
+	bind func(pressed input.KeyType) string
+}
+
+// listening is the button waiting for a key, nil when no key is bound
+var listening *KeyBindButton
+
+// ConsumeKey binds the key when a button is waiting for one, a key that was
+// bound should not do anything else
+func ConsumeKey(key input.KeyType) bool {
+	button := listening
+	if button == nil {
+		return false
+	}
+	listening = nil
+	glib.IdleAdd(func() {
+		button.SetLabel(button.bind(key))
+	})
+	return true
+}
+
+func NewKeyBindButton(settings *Settings, key SettingsKey, fallback input.KeyType) *KeyBindButton {
+	return newKeyListenButton(settings.GetKey(key, fallback).String(), func(pressed input.KeyType) string {
+		settings.SetValue(key, pressed)
+		return pressed.String()
+	})
+}
+
+// newKeyListenButton passes the next released key to bind, the button
+// shows the label bind returns. The key is only bound if the main window
+// hands it to ConsumeKey
+func newKeyListenButton(label string, bind func(pressed input.KeyType) string) (self *KeyBindButton) {
+	self = &KeyBindButton{gtk.NewButtonWithLabel(label), bind}
+
+	self.ConnectClicked(func() {
+		listening = self
+		self.SetLabel("press a key...")
+	})
+
+	return
+}
+
 type KeyboardChooser struct {
 	*gtk.DropDown
 
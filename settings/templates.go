@@ -0,0 +1,148 @@
+package settings
+
+import (
+	"log"
+	"tallyGo/countable"
+	"tallyGo/editdialog"
+	"tallyGo/input"
+
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// TemplatesSettingsGrid lists the hunt templates with their key bindings
+type TemplatesSettingsGrid struct {
+	*gtk.Grid
+
+	templates *gtk.Grid
+	settings  *Settings
+}
+
+func NewTemplatesSettingsGrid(settings *Settings) (self *TemplatesSettingsGrid) {
+	self = &TemplatesSettingsGrid{gtk.NewGrid(), gtk.NewGrid(), settings}
+	self.Grid.SetRowSpacing(6)
+	self.templates.SetRowSpacing(6)
+	self.templates.SetColumnSpacing(12)
+
+	buttons := gtk.NewBox(gtk.OrientationHorizontal, 6)
+	newButton := gtk.NewButtonWithLabel("New Template")
+	newButton.ConnectClicked(func() {
+		self.settings.AddTemplate(countable.NewTemplate("Template..."))
+	})
+	importButton := gtk.NewButtonWithLabel("Import")
+	importButton.ConnectClicked(self.importTemplates)
+	exportButton := gtk.NewButtonWithLabel("Export")
+	exportButton.ConnectClicked(self.exportTemplates)
+	buttons.Append(newButton)
+	buttons.Append(importButton)
+	buttons.Append(exportButton)
+
+	self.Grid.Attach(self.templates, 0, 0, 1, 1)
+	self.Grid.Attach(buttons, 0, 1, 1, 1)
+	self.update()
+	self.settings.ConnectChanged(TemplateList, func(any) {
+		self.update()
+	})
+
+	return
+}
+
+func (self *TemplatesSettingsGrid) grid() *gtk.Grid {
+	return self.Grid
+}
+
+func (self *TemplatesSettingsGrid) update() {
+	for child := self.templates.FirstChild(); child != nil; child = self.templates.FirstChild() {
+		self.templates.Remove(child)
+	}
+
+	for idx, header := range []string{"Template", "Increase", "Decrease"} {
+		label := gtk.NewLabel(header)
+		label.SetHAlign(gtk.AlignStart)
+		self.templates.Attach(label, idx, 0, 1, 1)
+	}
+
+	for idx, template := range self.settings.Templates {
+		template := template
+		row := idx + 1
+
+		label := gtk.NewLabel(template.Name)
+		label.SetHAlign(gtk.AlignStart)
+		label.SetTooltipText(template.Description())
+		self.templates.Attach(label, 0, row, 1, 1)
+
+		self.templates.Attach(newTemplateKeyButton(&template.Keys.Increase, self.settings.TemplatesChanged), 1, row, 1, 1)
+		self.templates.Attach(newTemplateKeyButton(&template.Keys.Decrease, self.settings.TemplatesChanged), 2, row, 1, 1)
+
+		editButton := gtk.NewButtonWithLabel("edit")
+		editButton.ConnectClicked(func() {
+			editdialog.NewTemplateDialog(template, self.settings.TemplatesChanged).Show()
+		})
+		self.templates.Attach(editButton, 3, row, 1, 1)
+
+		deleteButton := gtk.NewButtonWithLabel("delete")
+		deleteButton.ConnectClicked(func() {
+			self.settings.RemoveTemplate(template)
+		})
+		self.templates.Attach(deleteButton, 4, row, 1, 1)
+	}
+}
+
+// newTemplateKeyButton binds key to the next pressed key, escape removes
+// the binding, changed is called after the key was bound
+func newTemplateKeyButton(key *int, changed func()) *KeyBindButton {
+	label := func() string {
+		if *key == 0 {
+			return "none"
+		}
+		return input.KeyType(*key).String()
+	}
+	return newKeyListenButton(label(), func(pressed input.KeyType) string {
+		if pressed == input.KeyEscape {
+			*key = 0
+		} else {
+			*key = int(pressed)
+		}
+		changed()
+		return label()
+	})
+}
+
+func (self *TemplatesSettingsGrid) importTemplates() {
+	chooser := gtk.NewFileChooserNative("Import Templates", rootWindow(), gtk.FileChooserActionOpen, "Import", "Cancel")
+	chooser.ConnectResponse(func(responseID int) {
+		if responseID != int(gtk.ResponseAccept) {
+			return
+		}
+		templates, err := countable.ImportTemplates(chooser.File().Path())
+		if err != nil {
+			log.Println("[WARN]\tCould not import templates, Got Error: ", err)
+			return
+		}
+		for _, template := range templates {
+			self.settings.AddTemplate(template)
+		}
+	})
+	chooser.Show()
+}
+
+func (self *TemplatesSettingsGrid) exportTemplates() {
+	chooser := gtk.NewFileChooserNative("Export Templates", rootWindow(), gtk.FileChooserActionSave, "Export", "Cancel")
+	chooser.SetCurrentName("templates.json")
+	chooser.ConnectResponse(func(responseID int) {
+		if responseID != int(gtk.ResponseAccept) {
+			return
+		}
+		if err := countable.ExportTemplates(chooser.File().Path(), self.settings.Templates); err != nil {
+			log.Println("[WARN]\tCould not export templates, Got Error: ", err)
+		}
+	})
+	chooser.Show()
+}
+
+func rootWindow() *gtk.Window {
+	if mainWindow, ok := gtk.WindowListToplevels()[0].(*gtk.ApplicationWindow); ok {
+		return &mainWindow.Window
+	}
+	log.Println("[WARN]\tCould not find the root ApplicationWindow,\nis there more than one window opened")
+	return nil
+}
@@ -0,0 +1,197 @@
+package statistics
+
+import (
+	"fmt"
+	"math"
+	"tallyGo/countable"
+	EventBus "tallyGo/eventBus"
+	"time"
+
+	"github.com/diamondburned/gotk4/pkg/cairo"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+const (
+	histogramBins = 10
+	plotSize      = 280
+	plotMargin    = 24
+)
+
+// LuckPage shows how the luck percentiles of all completed phases compare to
+// the uniform distribution of a hunter with average luck
+type LuckPage struct {
+	*gtk.ScrolledWindow
+
+	list      *countable.CounterList
+	analysis  countable.LuckAnalysis
+	summary   *gtk.Label
+	linked    *gtk.Label
+	histogram *gtk.DrawingArea
+	qqPlot    *gtk.DrawingArea
+}
+
+func NewLuckPage(list *countable.CounterList) (self *LuckPage) {
+	self = &LuckPage{
+		gtk.NewScrolledWindow(),
+		list,
+		countable.LuckAnalysis{},
+		gtk.NewLabel(""),
+		gtk.NewLabel(""),
+		gtk.NewDrawingArea(),
+		gtk.NewDrawingArea(),
+	}
+
+	box := gtk.NewBox(gtk.OrientationVertical, 0)
+	box.AddCSSClass("statisticsBox")
+	self.SetChild(box)
+
+	plots := gtk.NewBox(gtk.OrientationHorizontal, 0)
+	plots.Append(newPlot("Histogram", self.histogram))
+	plots.Append(newPlot("Q-Q Plot", self.qqPlot))
+
+	box.Append(self.summary)
+	box.Append(plots)
+	box.Append(self.linked)
+
+	self.summary.SetXAlign(0)
+	self.summary.AddCSSClass("statisticsSummary")
+	self.linked.SetXAlign(0)
+	self.linked.AddCSSClass("statisticsSummary")
+	self.histogram.SetDrawFunc(self.drawHistogram)
+	self.qqPlot.SetDrawFunc(self.drawQQPlot)
+
+	// counters may have been removed or edited while the page was hidden
+	self.ConnectMap(self.Update)
+	EventBus.GetGlobalBus().Subscribe(countable.CompletedStatus, func(...interface{}) {
+		self.Update()
+	})
+	self.Update()
+
+	return
+}
+
+func newPlot(title string, area *gtk.DrawingArea) *gtk.Box {
+	box := gtk.NewBox(gtk.OrientationVertical, 0)
+	box.AddCSSClass("statisticsPlot")
+	label := gtk.NewLabel(title)
+	label.SetName("title")
+	box.Append(label)
+	box.Append(area)
+	area.SetContentWidth(plotSize)
+	area.SetContentHeight(plotSize)
+	return box
+}
+
+func (self *LuckPage) Update() {
+	self.analysis = self.list.AnalyzeLuck()
+
+	count := len(self.analysis.Percentiles)
+	if count == 0 {
+		self.summary.SetText("No completed phases yet")
+	} else {
+		verdict := "consistent with average luck"
+		if self.analysis.PValue < 0.05 {
+			verdict = "unlikely to be average luck"
+			if self.analysis.Mean() < 0.5 {
+				verdict += ", you are lucky"
+			} else {
+				verdict += ", you are unlucky"
+			}
+		}
+		self.summary.SetText(fmt.Sprintf(
+			"%d completed phases\nMean luck percentile: %.01f%%\nKS distance %.03f, p-value %.03f: %s",
+			count, self.analysis.Mean()*100, self.analysis.KS, self.analysis.PValue, verdict,
+		))
+	}
+
+	self.linked.SetText(linkedSummary(self.list.LinkedHunts()))
+
+	self.histogram.QueueDraw()
+	self.qqPlot.QueueDraw()
+}
+
+// linkedSummary shows the combined effort of hunts that were continued in
+// other counters
+func linkedSummary(hunts []countable.LinkedHunt) string {
+	if len(hunts) == 0 {
+		return ""
+	}
+	summary := "Linked hunts"
+	for _, hunt := range hunts {
+		status := "ongoing"
+		if hunt.IsCompleted() {
+			status = "completed"
+		}
+		summary += fmt.Sprintf(
+			"\n%s\n\t%d encounters in %s, %s",
+			hunt, hunt.GetEncounters(), hunt.GetTime().Round(time.Second), status,
+		)
+	}
+	return summary
+}
+
+func (self *LuckPage) drawHistogram(_ *gtk.DrawingArea, cr *cairo.Context, width, height int) {
+	drawAxes(cr, width, height)
+	if len(self.analysis.Percentiles) == 0 {
+		return
+	}
+
+	counts := self.analysis.Histogram(histogramBins)
+	expected := float64(len(self.analysis.Percentiles)) / histogramBins
+	highest := expected
+	for _, count := range counts {
+		highest = max(highest, float64(count))
+	}
+
+	plotWidth, plotHeight := float64(width-2*plotMargin), float64(height-2*plotMargin)
+	barWidth := plotWidth / histogramBins
+	cr.SetSourceRGB(0.35, 0.55, 0.85)
+	for idx, count := range counts {
+		barHeight := float64(count) / highest * plotHeight
+		cr.Rectangle(plotMargin+float64(idx)*barWidth+1, float64(height-plotMargin)-barHeight, barWidth-2, barHeight)
+	}
+	cr.Fill()
+
+	// every bin holds the same number of phases under average luck
+	y := float64(height-plotMargin) - expected/highest*plotHeight
+	cr.SetSourceRGB(0.85, 0.35, 0.35)
+	cr.SetLineWidth(2)
+	cr.MoveTo(plotMargin, y)
+	cr.LineTo(float64(width-plotMargin), y)
+	cr.Stroke()
+}
+
+func (self *LuckPage) drawQQPlot(_ *gtk.DrawingArea, cr *cairo.Context, width, height int) {
+	drawAxes(cr, width, height)
+
+	plotWidth, plotHeight := float64(width-2*plotMargin), float64(height-2*plotMargin)
+	toX := func(value float64) float64 { return plotMargin + value*plotWidth }
+	toY := func(value float64) float64 { return float64(height-plotMargin) - value*plotHeight }
+
+	cr.SetSourceRGB(0.85, 0.35, 0.35)
+	cr.SetLineWidth(2)
+	cr.MoveTo(toX(0), toY(0))
+	cr.LineTo(toX(1), toY(1))
+	cr.Stroke()
+
+	expected, observed := self.analysis.Quantiles()
+	cr.SetSourceRGB(0.35, 0.55, 0.85)
+	for idx := range expected {
+		cr.Arc(toX(expected[idx]), toY(observed[idx]), 3, 0, 2*math.Pi)
+		cr.Fill()
+	}
+}
+
+func drawAxes(cr *cairo.Context, width, height int) {
+	cr.SetSourceRGBA(0.5, 0.5, 0.5, 0.8)
+	cr.SetLineWidth(1)
+	cr.MoveTo(plotMargin, plotMargin)
+	cr.LineTo(plotMargin, float64(height-plotMargin))
+	cr.LineTo(float64(width-plotMargin), float64(height-plotMargin))
+	cr.Stroke()
+
+	cr.MoveTo(plotMargin, float64(height-plotMargin/4))
+	cr.ShowText("0%")
+	cr.MoveTo(float64(width-plotMargin-16), float64(height-plotMargin/4))
+	cr.ShowText("100%")
+}
@@ -1,6 +1,8 @@
 package treeview
 
 import (
+	. "tallyGo/countable"
+
 	"github.com/diamondburned/gotk4/pkg/glib/v2"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
 )
@@ -56,3 +58,21 @@ func (self *TreeRowContextMenu) NewRow(text string, callback func()) {
 	})
 	self.rows[text].AddController(gesture)
 }
+
+// NewUndoRows adds undo and redo rows that are only sensitive when there is
+// something to undo or redo, the row keeps the subscription
+func (self *TreeRowContextMenu) NewUndoRows(subscribed *subscriptions) {
+	self.NewRow("undo", func() {
+		GetUndoStack().Undo()
+	})
+	self.NewRow("redo", func() {
+		GetUndoStack().Redo()
+	})
+
+	setSensitive := func(...interface{}) {
+		self.rows["undo"].SetSensitive(GetUndoStack().CanUndo())
+		self.rows["redo"].SetSensitive(GetUndoStack().CanRedo())
+	}
+	setSensitive()
+	subscribed.subscribe(UndoStackChanged, setSensitive)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/diamondburned/gotk4/pkg/gio/v2"
 	"github.com/diamondburned/gotk4/pkg/glib/v2"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+	"github.com/diamondburned/gotk4/pkg/pango"
 )
 
 type CounterRow struct {
@@ -15,15 +16,21 @@ type CounterRow struct {
 	store *gio.ListStore
 
 	counter     *Counter
+	counters    *CounterList
 	contextMenu *TreeRowContextMenu
+
+	subscriptions
 }
 
-func NewCounterRow(counter *Counter, objMap map[*glib.Object]TreeRowObject) (self *CounterRow) {
+func NewCounterRow(counter *Counter, counters *CounterList, objMap map[*glib.Object]TreeRowObject) (self *CounterRow) {
 	self = &CounterRow{
 		TreeExpander: gtk.NewTreeExpander(),
 		store:        gio.NewListStore(glib.TypeObject),
 		counter:      counter,
+		counters:     counters,
 		contextMenu:  newTreeRowContextMenu(),
+
+		subscriptions: nil,
 	}
 
 	// remove the default shortcuts as it will mess with increasing and decreasing counters
@@ -31,7 +38,7 @@ func NewCounterRow(counter *Counter, objMap map[*glib.Object]TreeRowObject) (sel
 		self.TreeExpander.RemoveController(shortcutCtrl)
 	}
 
-	box := NewCounterRowBox(self.counter)
+	box := NewCounterRowBox(self.counter, &self.subscriptions)
 	self.TreeExpander.SetChild(box)
 
 	self.SetupContextMenu()
@@ -40,7 +47,7 @@ func NewCounterRow(counter *Counter, objMap map[*glib.Object]TreeRowObject) (sel
 		self.NewPhase(p, objMap)
 	}
 
-	EventBus.GetGlobalBus().Subscribe(PhaseAdded, func(args ...interface{}) {
+	self.subscribe(PhaseAdded, func(args ...interface{}) {
 		counter := args[0].(*Counter)
 		newPhase := args[1].(*Phase)
 
@@ -49,7 +56,36 @@ func NewCounterRow(counter *Counter, objMap map[*glib.Object]TreeRowObject) (sel
 		}
 	})
 
-	EventBus.GetGlobalBus().Subscribe(PhaseRemoved, func(args ...interface{}) {
+	self.subscribe(PhaseInserted, func(args ...interface{}) {
+		counter := args[0].(*Counter)
+		phase := args[1].(*Phase)
+		idx := args[2].(int)
+
+		if self.counter == counter {
+			row := NewPhaseRow(phase, self.counters)
+			objMap[row.Object] = row
+			self.store.Insert(uint(idx), row.Object)
+		}
+	})
+
+	self.subscribe(PhaseMoved, func(args ...interface{}) {
+		counter := args[0].(*Counter)
+		phase := args[1].(*Phase)
+		idx := args[2].(int)
+
+		if self.counter == counter {
+			for i := uint(0); i < self.store.NItems(); i++ {
+				if rowObj := findRowObj(objMap, self.store.Item(i)); rowObj != nil && rowObj.Countable() == phase {
+					item := self.store.Item(i)
+					self.store.Remove(i)
+					self.store.Insert(uint(idx), item)
+					break
+				}
+			}
+		}
+	})
+
+	self.subscribe(PhaseRemoved, func(args ...interface{}) {
 		counter := args[0].(*Counter)
 		phase := args[1].(*Phase)
 
@@ -88,62 +124,124 @@ func (self *CounterRow) Countable() Countable {
 func (self *CounterRow) SetupContextMenu() {
 	self.contextMenu.SetParent(&self.TreeExpander.Widget)
 	self.contextMenu.NewRow("mark complete", func() {
-		if self.counter.IsCompleted() {
-			self.contextMenu.rows["mark complete"].SetText("mark complete")
-			self.counter.SetCompleted(false)
-		} else {
-			self.contextMenu.rows["mark complete"].SetText("mark incomplete")
-			self.counter.SetCompleted(true)
-		}
+		GetUndoStack().Do(NewSetCompletedCommand(self.counter, !self.counter.IsCompleted()))
+	})
+	self.setCompletedLabel()
+	self.subscribe(CompletedStatus, func(...interface{}) {
+		self.setCompletedLabel()
+	})
+	self.contextMenu.NewRow("shiny found", func() {
+		dialog := editdialog.NewFinishDialog(self.counter)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("break chain", func() {
+		GetUndoStack().Do(NewBreakChainCommand(self.counter))
 	})
-	if self.counter.IsCompleted() {
-		self.contextMenu.rows["mark complete"].SetText("mark incomplete")
-	}
 	self.contextMenu.NewRow("edit", func() {
 		dialog := editdialog.NewEditDialog(self.counter)
 		dialog.Show()
 	})
+	self.contextMenu.NewRow("duplicate", func() {
+		self.counters.DuplicateCounter(self.counter, false)
+	})
+	self.contextMenu.NewRow("duplicate with phases", func() {
+		self.counters.DuplicateCounter(self.counter, true)
+	})
+	self.contextMenu.NewRow("continue in another game", func() {
+		dialog := editdialog.NewEditDialog(self.counters.ContinueCounter(self.counter))
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("save as template", func() {
+		EventBus.GetGlobalBus().SendSignal(SaveTemplate, self.counter)
+	})
+	self.contextMenu.NewRow("move to group", func() {
+		dialog := editdialog.NewMoveDialog(self.counter, self.counters)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("archive", func() {
+		if self.counter.IsCompleted() {
+			GetUndoStack().Do(NewArchiveCommand(self.counters, self.counter))
+		}
+	})
 	self.contextMenu.NewRow("delete", func() {
 		EventBus.GetGlobalBus().SendSignal(RemoveCounter, self.counter)
 	})
+	self.contextMenu.NewUndoRows(&self.subscriptions)
+	self.setCompletedLabel()
+}
+
+// setCompletedLabel also only lets finished hunts be archived
+func (self *CounterRow) setCompletedLabel() {
+	if archive, ok := self.contextMenu.rows["archive"]; ok {
+		archive.SetSensitive(self.counter.IsCompleted())
+	}
+	if self.counter.IsCompleted() {
+		self.contextMenu.rows["mark complete"].SetText("mark incomplete")
+	} else {
+		self.contextMenu.rows["mark complete"].SetText("mark complete")
+	}
 }
 
 func (self *CounterRow) NewPhase(phase *Phase, objMap map[*glib.Object]TreeRowObject) {
-	row := NewPhaseRow(phase)
+	row := NewPhaseRow(phase, self.counters)
 	objMap[row.Object] = row
 	self.store.Append(row.Object)
 }
 
 type CounterRowBox struct {
 	*gtk.Box
-	label  *gtk.Label
-	button *gtk.Button
+	label       *gtk.Label
+	targetLabel *gtk.Label
+	button      *gtk.Button
 }
 
-func NewCounterRowBox(counter *Counter) (self *CounterRowBox) {
+func NewCounterRowBox(counter *Counter, subscribed *subscriptions) (self *CounterRowBox) {
 	self = &CounterRowBox{
-		Box:    gtk.NewBox(gtk.OrientationHorizontal, 0),
-		label:  gtk.NewLabel(counter.Name),
-		button: gtk.NewButtonWithLabel("+"),
+		Box:         gtk.NewBox(gtk.OrientationHorizontal, 0),
+		label:       gtk.NewLabel(counter.Name),
+		targetLabel: gtk.NewLabel(rowDescription(counter)),
+		button:      gtk.NewButtonWithLabel("+"),
 	}
 
-	self.Box.Append(self.label)
+	labels := gtk.NewBox(gtk.OrientationVertical, 0)
+	labels.SetHExpand(true)
+	labels.Append(self.label)
+	labels.Append(self.targetLabel)
+
+	self.Box.Append(labels)
 	self.Box.Append(self.button)
 	self.Box.AddCSSClass("counterBoxRow")
 
-	self.label.SetHExpand(true)
 	self.label.SetXAlign(0)
+	self.targetLabel.SetXAlign(0)
+	self.targetLabel.SetEllipsize(pango.EllipsizeEnd)
+	self.targetLabel.AddCSSClass("counterTargetLabel")
 
 	self.button.SetHAlign(gtk.AlignEnd)
 	self.button.ConnectClicked(func() {
-		counter.NewPhase()
+		GetUndoStack().Do(NewNewPhaseCommand(counter))
 	})
 
-	EventBus.GetGlobalBus().Subscribe(NameChanged, func(args ...interface{}) {
+	subscribed.subscribe(NameChanged, func(args ...interface{}) {
 		if c, ok := args[0].(*Counter); ok && counter == c {
 			self.label.SetText(counter.Name)
 		}
 	})
 
+	subscribed.subscribe(TargetChanged, func(args ...interface{}) {
+		if counter == args[0].(*Counter) {
+			self.targetLabel.SetText(rowDescription(counter))
+		}
+	})
+
 	return
 }
+
+// rowDescription adds the tags of the counter to its description
+func rowDescription(counter *Counter) string {
+	description := counter.Description()
+	for _, tag := range counter.Tags {
+		description += " #" + tag
+	}
+	return description
+}
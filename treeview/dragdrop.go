@@ -0,0 +1,81 @@
+package treeview
+
+import (
+	. "tallyGo/countable"
+
+	"github.com/diamondburned/gotk4/pkg/core/glib"
+	"github.com/diamondburned/gotk4/pkg/gdk/v4"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+)
+
+// setupDragAndDrop lets counters be dragged onto counters and groups and
+// phases onto phases and counters, rows are bound many times but set up
+// only once
+func (self *CounterTreeView) setupDragAndDrop(rowObj TreeRowObject) {
+	if self.draggable[rowObj] {
+		return
+	}
+	self.draggable[rowObj] = true
+
+	source := gtk.NewDragSource()
+	source.SetActions(gdk.ActionMove)
+	source.ConnectPrepare(func(x, y float64) *gdk.ContentProvider {
+		if _, isGroup := rowObj.(*GroupRow); isGroup {
+			return nil
+		}
+		self.dragged = rowObj.Countable()
+		// the dragged countable is kept by the treeview, the value only
+		// marks the drag as one of ours
+		return gdk.NewContentProviderForValue(glib.NewValue("tallyGo"))
+	})
+	source.ConnectDragEnd(func(gdk.Dragger, bool) {
+		self.dragged = nil
+	})
+	rowObj.GetWidget().AddController(source)
+
+	target := gtk.NewDropTarget(glib.TypeString, gdk.ActionMove)
+	target.ConnectDrop(func(glib.Value, float64, float64) bool {
+		return self.drop(rowObj)
+	})
+	rowObj.GetWidget().AddController(target)
+}
+
+func (self *CounterTreeView) drop(rowObj TreeRowObject) bool {
+	switch dragged := self.dragged.(type) {
+	case *Counter:
+		switch target := rowObj.(type) {
+		case *GroupRow:
+			self.counters.MoveToGroup(dragged, target.group)
+			return true
+		case *CounterRow:
+			// sorted counters are shown in an order that can't be changed
+			if target.counter == dragged || self.sortMode() != SortManual {
+				return false
+			}
+			if idx, ok := self.counters.GetIdx(target.counter); ok {
+				GetUndoStack().Do(NewMoveCounterCommand(self.counters, dragged, idx))
+				return true
+			}
+		}
+	case *Phase:
+		counter, ok := self.counters.GetCounterOfPhase(dragged)
+		if !ok {
+			return false
+		}
+		if target, isCounter := rowObj.(*CounterRow); isCounter && target.counter != counter {
+			GetUndoStack().Do(NewMovePhaseToCounterCommand(counter, target.counter, dragged))
+			return true
+		}
+		target, ok := rowObj.(*PhaseRow)
+		if !ok || target.phase == dragged {
+			return false
+		}
+		for idx, phase := range counter.Phases {
+			if phase == target.phase {
+				GetUndoStack().Do(NewMovePhaseCommand(counter, dragged, idx))
+				return true
+			}
+		}
+	}
+	return false
+}
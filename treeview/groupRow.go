@@ -0,0 +1,154 @@
+package treeview
+
+import (
+	"fmt"
+	. "tallyGo/countable"
+	"tallyGo/editdialog"
+
+	"github.com/diamondburned/gotk4/pkg/gio/v2"
+	"github.com/diamondburned/gotk4/pkg/glib/v2"
+	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+	"github.com/diamondburned/gotk4/pkg/pango"
+)
+
+// GroupRow is a collapsible row holding the rows of the group's subgroups
+// and counters
+type GroupRow struct {
+	*gtk.TreeExpander
+	store *gio.ListStore
+	model *gtk.FilterListModel
+
+	group       *Group
+	counters    *CounterList
+	listRow     *gtk.TreeListRow
+	contextMenu *TreeRowContextMenu
+
+	subscriptions
+}
+
+// filter hides the rows of the group that do not match the search
+func NewGroupRow(group *Group, counters *CounterList, filter *gtk.Filter) (self *GroupRow) {
+	self = &GroupRow{
+		TreeExpander: gtk.NewTreeExpander(),
+		store:        gio.NewListStore(glib.TypeObject),
+		model:        nil,
+		group:        group,
+		counters:     counters,
+		listRow:      nil,
+		contextMenu:  newTreeRowContextMenu(),
+
+		subscriptions: nil,
+	}
+
+	// remove the default shortcuts as it will mess with increasing and decreasing counters
+	if shortcutCtrl, ok := self.TreeExpander.ObserveControllers().Item(1).Cast().(*gtk.ShortcutController); ok {
+		self.TreeExpander.RemoveController(shortcutCtrl)
+	}
+
+	self.model = gtk.NewFilterListModel(self.store, filter)
+	self.TreeExpander.SetChild(NewGroupRowBox(group, &self.subscriptions))
+	self.SetupContextMenu()
+
+	return
+}
+
+func (self *GroupRow) Store() *gio.ListStore {
+	return self.store
+}
+
+func (self *GroupRow) Model() *gio.ListModel {
+	return &self.model.ListModel
+}
+
+func (self *GroupRow) GetWidget() *gtk.Widget {
+	return &self.Widget
+}
+
+func (self *GroupRow) Expander() *gtk.TreeExpander {
+	return self.TreeExpander
+}
+
+func (self *GroupRow) Countable() Countable {
+	return self.group
+}
+
+// setListRow restores the expanded state of the group and keeps it up to
+// date, rows are bound again every time they scroll into view
+func (self *GroupRow) setListRow(row *gtk.TreeListRow) {
+	if self.listRow == row {
+		return
+	}
+	self.listRow = row
+	glib.IdleAdd(func() {
+		row.SetExpanded(self.group.IsExpanded)
+	})
+	row.NotifyProperty("expanded", func() {
+		if self.listRow == row {
+			self.group.IsExpanded = row.Expanded()
+		}
+	})
+}
+
+func (self *GroupRow) SetupContextMenu() {
+	self.contextMenu.SetParent(&self.TreeExpander.Widget)
+	self.contextMenu.NewRow("new group", func() {
+		dialog := editdialog.NewGroupDialog(self.group, self.counters)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("rename", func() {
+		dialog := editdialog.NewEditDialog(self.group)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("delete", func() {
+		self.counters.RemoveGroup(self.group)
+	})
+}
+
+type GroupRowBox struct {
+	*gtk.Box
+	label        *gtk.Label
+	summaryLabel *gtk.Label
+}
+
+func NewGroupRowBox(group *Group, subscribed *subscriptions) (self *GroupRowBox) {
+	self = &GroupRowBox{
+		Box:          gtk.NewBox(gtk.OrientationVertical, 0),
+		label:        gtk.NewLabel(group.Name),
+		summaryLabel: gtk.NewLabel(""),
+	}
+
+	self.Box.Append(self.label)
+	self.Box.Append(self.summaryLabel)
+	self.Box.SetHExpand(true)
+	self.Box.AddCSSClass("counterBoxRow")
+
+	self.label.SetXAlign(0)
+	self.summaryLabel.SetXAlign(0)
+	self.summaryLabel.SetEllipsize(pango.EllipsizeEnd)
+	self.summaryLabel.AddCSSClass("counterTargetLabel")
+
+	setSummary := func(...interface{}) {
+		self.summaryLabel.SetText(groupSummary(group))
+	}
+	setSummary()
+
+	subscribed.subscribe(NameChanged, func(args ...interface{}) {
+		if g, ok := args[0].(*Group); ok && group == g {
+			self.label.SetText(group.Name)
+		}
+	})
+	subscribed.subscribe(CountChanged, setSummary)
+	subscribed.subscribe(CompletedStatus, setSummary)
+
+	return
+}
+
+// groupSummary shows the totals of the group, luck is only shown once
+// there is a shiny in the group
+func groupSummary(group *Group) string {
+	summary := fmt.Sprintf("%d counters | %d encounters", len(group.AllCounters()), group.GetCount())
+	if group.Completed() > 0 {
+		summary += fmt.Sprintf(" | luck %.1f%%", group.Luck()*100-50)
+	}
+	return summary
+}
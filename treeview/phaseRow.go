@@ -12,29 +12,36 @@ import (
 type PhaseRow struct {
 	*gtk.Box
 	phase       *Phase
+	counters    *CounterList
 	contextMenu *TreeRowContextMenu
+
+	subscriptions
 }
 
-func NewPhaseRow(phase *Phase) (self *PhaseRow) {
+func NewPhaseRow(phase *Phase, counters *CounterList) (self *PhaseRow) {
 	self = &PhaseRow{
 		Box:         gtk.NewBox(gtk.OrientationHorizontal, 0),
 		phase:       phase,
+		counters:    counters,
 		contextMenu: newTreeRowContextMenu(),
+
+		subscriptions: nil,
 	}
 
 	box := NewPhaseRowBox(phase)
 	self.Box.Append(box)
 	self.Box.SetName("PhaseRow")
 
-	EventBus.GetGlobalBus().Subscribe(NameChanged, func(args ...interface{}) {
+	self.subscribe(NameChanged, func(args ...interface{}) {
 		if self.phase == args[0] {
 			box.setLabel(phase.Name)
 		}
 	})
 
-	EventBus.GetGlobalBus().Subscribe(CompletedStatus, func(args ...interface{}) {
+	self.subscribe(CompletedStatus, func(args ...interface{}) {
 		if self.phase == args[0] {
 			box.setPadlock(phase)
+			self.setCompletedLabel()
 		}
 	})
 
@@ -66,24 +73,43 @@ func (self *PhaseRow) Countable() Countable {
 func (self *PhaseRow) SetupContextMenu() {
 	self.contextMenu.SetParent(&self.Box.Widget)
 	self.contextMenu.NewRow("mark complete", func() {
-		if self.phase.IsCompleted {
-			self.contextMenu.rows["mark complete"].SetText("mark complete")
-			self.phase.SetCompleted(false)
-		} else {
-			self.contextMenu.rows["mark complete"].SetText("mark incomplete")
-			self.phase.SetCompleted(true)
-		}
+		GetUndoStack().Do(NewSetCompletedCommand(self.phase, !self.phase.IsCompleted))
+	})
+	self.setCompletedLabel()
+	self.contextMenu.NewRow("shiny found", func() {
+		dialog := editdialog.NewFinishDialog(self.phase)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("break chain", func() {
+		GetUndoStack().Do(NewBreakChainCommand(self.phase))
 	})
-	if self.phase.IsCompleted {
-		self.contextMenu.rows["mark complete"].SetText("mark incomplete")
-	}
 	self.contextMenu.NewRow("edit", func() {
 		dialog := editdialog.NewEditDialog(self.phase)
 		dialog.Show()
 	})
+	self.contextMenu.NewRow("split", func() {
+		dialog := editdialog.NewSplitDialog(self.phase)
+		dialog.Show()
+	})
+	self.contextMenu.NewRow("merge with next", func() {
+		EventBus.GetGlobalBus().SendSignal(MergePhase, self.phase)
+	})
+	self.contextMenu.NewRow("move to counter", func() {
+		dialog := editdialog.NewMovePhaseDialog(self.phase, self.counters)
+		dialog.Show()
+	})
 	self.contextMenu.NewRow("delete", func() {
 		EventBus.GetGlobalBus().SendSignal(RemovePhase, self.phase)
 	})
+	self.contextMenu.NewUndoRows(&self.subscriptions)
+}
+
+func (self *PhaseRow) setCompletedLabel() {
+	if self.phase.IsCompleted {
+		self.contextMenu.rows["mark complete"].SetText("mark incomplete")
+	} else {
+		self.contextMenu.rows["mark complete"].SetText("mark complete")
+	}
 }
 
 type PhaseRowBox struct {
@@ -113,6 +139,12 @@ func (self *PhaseRowBox) setPadlock(phase *Phase) {
 	} else {
 		self.image.SetFromIconName("padlock-unlocked")
 	}
+
+	if result, ok := phase.GetResult(); ok {
+		self.Box.SetTooltipText(result.String())
+	} else {
+		self.Box.SetTooltipText("")
+	}
 }
 
 func (self *PhaseRowBox) setLabel(name string) {
@@ -3,11 +3,14 @@ package treeview
 import (
 	"log"
 	. "tallyGo/countable"
+	"tallyGo/editdialog"
 	EventBus "tallyGo/eventBus"
+	"tallyGo/settings"
 
 	"github.com/diamondburned/gotk4/pkg/core/glib"
 	"github.com/diamondburned/gotk4/pkg/gio/v2"
 	"github.com/diamondburned/gotk4/pkg/gtk/v4"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -20,27 +23,68 @@ type TreeRowObject interface {
 	GetWidget() *gtk.Widget
 	Expander() *gtk.TreeExpander
 	Countable() Countable
+	// release unsubscribes the row from the global bus
+	release()
+}
+
+// subscriptions of a row, they are released when the row is rebuilt
+type subscriptions []EventBus.Subscription
+
+func (self *subscriptions) subscribe(kind EventBus.Signal, callback func(...interface{})) {
+	*self = append(*self, EventBus.GetGlobalBus().Subscribe(kind, callback))
+}
+
+func (self *subscriptions) release() {
+	for _, subscription := range *self {
+		EventBus.GetGlobalBus().Unsubscribe(subscription)
+	}
+	*self = nil
 }
 
 type CounterTreeView struct {
 	*gtk.ListView
 
-	store   *gio.ListStore
-	objects map[*glib.Object]TreeRowObject
+	store    *gio.ListStore
+	objects  map[*glib.Object]TreeRowObject
+	counters *CounterList
+	settings *settings.Settings
+	header   *gtk.Box
+	search   *gtk.SearchEntry
+	sort     *gtk.DropDown
+	filter   *gtk.CustomFilter
+	// kept to select the active counters again after rebuilding
+	selection *gtk.MultiSelection
+
+	// rows that can be dragged and dropped onto
+	draggable map[TreeRowObject]bool
+	dragged   Countable
 }
 
-func NewCounterTreeView(counters *CounterList) (self *CounterTreeView) {
+func NewCounterTreeView(counters *CounterList, settings *settings.Settings) (self *CounterTreeView) {
 	self = &CounterTreeView{
 		ListView: nil,
 		store:    nil,
 		objects:  map[*glib.Object]TreeRowObject{},
+		counters: counters,
+		settings: settings,
+		header:   gtk.NewBox(gtk.OrientationHorizontal, 0),
+		search:   gtk.NewSearchEntry(),
+		sort:     nil,
+		filter:   nil,
+
+		selection: nil,
+
+		draggable: map[TreeRowObject]bool{},
+		dragged:   nil,
 	}
 
 	self.ListView = gtk.NewListView(nil, nil)
 	self.AddCSSClass("counterTreeView")
 
 	self.store = gio.NewListStore(glib.TypeObject)
-	treeListModel := gtk.NewTreeListModel(self.store, false, false, self.createTreeModel)
+	self.filter = gtk.NewCustomFilter(self.matches)
+	filterModel := gtk.NewFilterListModel(self.store, &self.filter.Filter)
+	treeListModel := gtk.NewTreeListModel(filterModel, false, false, self.createTreeModel)
 	selectionModel := gtk.NewMultiSelection(treeListModel)
 	selectionModel.ConnectSelectionChanged(func(uint, uint) {
 		var selection []Countable
@@ -60,41 +104,136 @@ func NewCounterTreeView(counters *CounterList) (self *CounterTreeView) {
 		counters.SetActive(selection...)
 	})
 	self.SetModel(selectionModel)
+	self.selection = selectionModel
 
 	factory := gtk.NewSignalListItemFactory()
 	factory.ConnectBind(self.bindRow)
 	self.SetFactory(&factory.ListItemFactory)
 
-	for _, c := range counters.List {
-		row := NewCounterRow(c, self.objects)
-		self.objects[row.Object] = row
-		self.store.Append(row.Object)
-		sep := gtk.NewSeparator(gtk.OrientationHorizontal)
-		self.store.Append(sep.Object)
-	}
-
-	addCounterButton := gtk.NewButtonWithLabel("New Counter")
-	addCounterButton.ConnectClicked(func() {
-		counters.NewCounter()
-	})
-	self.store.Append(addCounterButton.Object)
+	self.setupSearch()
+	self.setupSort()
+	self.Rebuild()
 
 	EventBus.GetGlobalBus().Subscribe(CounterAdded, func(args ...interface{}) {
 		self.AddCounter(args[0].(*Counter))
 	})
 
+	// restored counters may belong to a group, so the whole tree is rebuilt
+	EventBus.GetGlobalBus().Subscribe(CounterInserted, func(args ...interface{}) {
+		self.Rebuild()
+	})
+
 	EventBus.GetGlobalBus().Subscribe(CounterRemoved, func(args ...interface{}) {
 		counter := args[0].(*Counter)
 		if idx, ok := self.GetIdxFromCounter(counter); ok {
 			self.store.Remove(idx)
 			// remove the Separator
 			self.store.Remove(idx)
+			return
 		}
+		self.removeFromGroups(self.store, counter)
+	})
+
+	EventBus.GetGlobalBus().Subscribe(GroupsChanged, func(args ...interface{}) {
+		self.Rebuild()
+	})
+
+	EventBus.GetGlobalBus().Subscribe(CounterMoved, func(args ...interface{}) {
+		self.Rebuild()
+	})
+
+	// counting changes the order of every sort mode except the manual one
+	EventBus.GetGlobalBus().Subscribe(CountChanged, func(...interface{}) {
+		self.resort()
+	})
+	EventBus.GetGlobalBus().Subscribe(TimeChanged, func(...interface{}) {
+		self.resort()
 	})
 
 	return
 }
 
+// Header holds the search and the sort mode, it is not part of the list so
+// it stays in place while scrolling
+func (self *CounterTreeView) Header() *gtk.Box {
+	return self.header
+}
+
+func (self *CounterTreeView) setupSearch() {
+	self.search.SetHExpand(true)
+	self.header.Append(self.search)
+	self.search.SetObjectProperty("placeholder-text", "Search, e.g. tag:kanto status:active")
+	self.search.AddCSSClass("counterSearchEntry")
+	if query, ok := self.settings.GetValue(settings.TreeViewFilter).(string); ok {
+		self.search.SetText(query)
+	}
+	self.search.ConnectSearchChanged(func() {
+		self.settings.SetValue(settings.TreeViewFilter, self.search.Text())
+		self.filter.Changed(gtk.FilterChangeDifferent)
+	})
+
+	// counters may stop matching when they are edited or completed
+	for _, signal := range []EventBus.Signal{NameChanged, TargetChanged, CompletedStatus} {
+		EventBus.GetGlobalBus().Subscribe(signal, func(...interface{}) {
+			if self.search.Text() != "" {
+				self.filter.Changed(gtk.FilterChangeDifferent)
+			}
+		})
+	}
+}
+
+func (self *CounterTreeView) setupSort() {
+	names := []string{}
+	for _, mode := range SortModes() {
+		names = append(names, mode.String())
+	}
+	self.sort = gtk.NewDropDownFromStrings(names)
+	self.sort.SetTooltipText("Sort counters, drag counters to change the manual order")
+	self.sort.AddCSSClass("counterSortDropDown")
+	self.sort.SetSelected(uint(self.sortMode()))
+	self.sort.NotifyProperty("selected", func() {
+		self.settings.SetValue(settings.TreeViewSort, SortModes()[self.sort.Selected()])
+		self.Rebuild()
+	})
+	self.header.Append(self.sort)
+}
+
+// sortMode is a number after loading the save file
+func (self *CounterTreeView) sortMode() SortMode {
+	switch mode := self.settings.GetValue(settings.TreeViewSort).(type) {
+	case SortMode:
+		return mode
+	case float64:
+		return SortMode(mode)
+	}
+	return SortManual
+}
+
+// matches hides counters that do not match the search and groups without
+// matching counters, separators are only shown without a search
+func (self *CounterTreeView) matches(item *glib.Object) bool {
+	query := self.search.Text()
+	if query == "" {
+		return true
+	}
+
+	switch rowObj := findRowObj(self.objects, item).(type) {
+	case *CounterRow:
+		return self.counters.Matches(rowObj.counter, query)
+	case *GroupRow:
+		for _, c := range rowObj.group.AllCounters() {
+			if self.counters.Matches(c, query) {
+				return true
+			}
+		}
+		return false
+	case nil:
+		_, isButton := item.Cast().(*gtk.Button)
+		return isButton
+	}
+	return true
+}
+
 func (self *CounterTreeView) createTreeModel(gObj *glib.Object) *gio.ListModel {
 	return findRowObj(self.objects, gObj).Model()
 }
@@ -108,10 +247,15 @@ func (self *CounterTreeView) bindRow(listItem *gtk.ListItem) {
 		if rowObj := findRowObj(self.objects, gObj); rowObj != nil {
 			rowObj.Expander().SetListRow(row)
 			listItem.SetChild(rowObj.GetWidget())
+			if groupRow, ok := rowObj.(*GroupRow); ok {
+				groupRow.setListRow(row)
+			}
+			self.setupDragAndDrop(rowObj)
 		}
 	case "GtkBox":
 		if rowObj := findRowObj(self.objects, gObj); rowObj != nil {
 			listItem.SetChild(rowObj.GetWidget())
+			self.setupDragAndDrop(rowObj)
 		}
 	case "GtkSeparator":
 		listItem.SetSelectable(false)
@@ -126,12 +270,182 @@ func (self *CounterTreeView) bindRow(listItem *gtk.ListItem) {
 	}
 }
 
+// Rebuild recreates all rows, groups come first followed by the counters
+// that are not in any group. The active counters stay selected
+func (self *CounterTreeView) Rebuild() {
+	active := self.counters.GetActive()
+	for _, rowObj := range self.objects {
+		rowObj.release()
+	}
+	self.store.RemoveAll()
+	self.objects = map[*glib.Object]TreeRowObject{}
+	self.draggable = map[TreeRowObject]bool{}
+
+	for _, g := range self.counters.Groups {
+		self.store.Append(self.newGroupRow(g).Object)
+	}
+	if len(self.counters.Groups) > 0 {
+		self.store.Append(gtk.NewSeparator(gtk.OrientationHorizontal).Object)
+	}
+
+	for _, c := range self.counters.Sorted(self.sortMode()) {
+		if _, ok := self.counters.GetGroupOf(c); ok {
+			continue
+		}
+		row := NewCounterRow(c, self.counters, self.objects)
+		self.objects[row.Object] = row
+		self.store.Append(row.Object)
+		sep := gtk.NewSeparator(gtk.OrientationHorizontal)
+		self.store.Append(sep.Object)
+	}
+
+	self.store.Append(self.newCounterButton().Object)
+
+	addGroupButton := gtk.NewButtonWithLabel("New Group")
+	addGroupButton.ConnectClicked(func() {
+		dialog := editdialog.NewGroupDialog(nil, self.counters)
+		dialog.Show()
+	})
+	self.store.Append(addGroupButton.Object)
+
+	self.selectActive(active)
+}
+
+// selectActive selects the rows of the countables that were active before
+// rebuilding, rows inside collapsed rows can't be selected
+func (self *CounterTreeView) selectActive(active []Countable) {
+	if self.selection == nil {
+		return
+	}
+	for i := uint(0); i < self.selection.NItems(); i++ {
+		rowObj := findRowObj(self.objects, self.selection.Item(i).Cast().(*gtk.TreeListRow).Item())
+		if rowObj != nil && slices.Contains(active, rowObj.Countable()) {
+			self.selection.SelectItem(i, false)
+		}
+	}
+}
+
+// resort rebuilds the rows when the counters are no longer shown in the
+// order of the sort mode
+func (self *CounterTreeView) resort() {
+	if self.sortMode() == SortManual {
+		return
+	}
+	if !slices.Equal(self.shownCounters(self.store), self.sortedCounters()) {
+		self.Rebuild()
+	}
+}
+
+// shownCounters returns the counters in the order of their rows
+func (self *CounterTreeView) shownCounters(store *gio.ListStore) (counters []*Counter) {
+	for i := uint(0); i < store.NItems(); i++ {
+		switch rowObj := findRowObj(self.objects, store.Item(i)).(type) {
+		case *CounterRow:
+			counters = append(counters, rowObj.counter)
+		case *GroupRow:
+			counters = append(counters, self.shownCounters(rowObj.Store())...)
+		}
+	}
+	return
+}
+
+// sortedCounters returns the counters in the order Rebuild shows them
+func (self *CounterTreeView) sortedCounters() (counters []*Counter) {
+	var inGroup func(group *Group)
+	inGroup = func(group *Group) {
+		for _, g := range group.Groups {
+			inGroup(g)
+		}
+		grouped := group.ListedCounters()
+		SortCounters(grouped, self.sortMode())
+		counters = append(counters, grouped...)
+	}
+	for _, g := range self.counters.Groups {
+		inGroup(g)
+	}
+
+	for _, c := range self.counters.Sorted(self.sortMode()) {
+		if _, ok := self.counters.GetGroupOf(c); !ok {
+			counters = append(counters, c)
+		}
+	}
+	return
+}
+
+func (self *CounterTreeView) newGroupRow(group *Group) *GroupRow {
+	row := NewGroupRow(group, self.counters, &self.filter.Filter)
+	self.objects[row.Object] = row
+	for _, g := range group.Groups {
+		row.Store().Append(self.newGroupRow(g).Object)
+	}
+	counters := group.ListedCounters()
+	SortCounters(counters, self.sortMode())
+	for _, c := range counters {
+		counterRow := NewCounterRow(c, self.counters, self.objects)
+		self.objects[counterRow.Object] = counterRow
+		row.Store().Append(counterRow.Object)
+	}
+	return row
+}
+
+// newCounterButton offers the hunt templates when there are any
+func (self *CounterTreeView) newCounterButton() *gtk.Button {
+	button := gtk.NewButtonWithLabel("New Counter")
+	button.ConnectClicked(func() {
+		if len(self.settings.Templates) == 0 {
+			self.counters.NewCounter()
+			return
+		}
+
+		menu := newTreeRowContextMenu()
+		menu.Popover.SetParent(button)
+		menu.ConnectClosed(func() {
+			menu.Unparent()
+		})
+		menu.NewRow("Blank", func() {
+			self.counters.NewCounter()
+		})
+		for _, template := range self.settings.Templates {
+			template := template
+			menu.NewRow(template.Name, func() {
+				self.counters.NewCounterFromTemplate(template)
+			})
+		}
+		menu.Popup()
+	})
+	return button
+}
+
+// footerSize is the number of buttons after the last counter
+const footerSize = 2
+
+// AddCounter appends the row of a new counter, sorted counters move it to
+// its place
 func (self *CounterTreeView) AddCounter(counter *Counter) {
-	row := NewCounterRow(counter, self.objects)
+	row := NewCounterRow(counter, self.counters, self.objects)
 	self.objects[row.Object] = row
-	self.store.Insert(self.store.NItems()-1, row.Object)
+	self.store.Insert(self.store.NItems()-footerSize, row.Object)
 	sep := gtk.NewSeparator(gtk.OrientationHorizontal)
-	self.store.Insert(self.store.NItems()-1, sep.Object)
+	self.store.Insert(self.store.NItems()-footerSize, sep.Object)
+	self.resort()
+}
+
+// removeFromGroups removes the row of a counter that is inside a group
+func (self *CounterTreeView) removeFromGroups(store *gio.ListStore, counter *Counter) bool {
+	for i := uint(0); i < store.NItems(); i++ {
+		switch rowObj := findRowObj(self.objects, store.Item(i)).(type) {
+		case *CounterRow:
+			if rowObj.Countable() == counter && store != self.store {
+				store.Remove(i)
+				return true
+			}
+		case *GroupRow:
+			if self.removeFromGroups(rowObj.Store(), counter) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (self *CounterTreeView) GetIdxFromCounter(counter *Counter) (uint, bool) {